@@ -132,6 +132,12 @@ export class Emulator extends SignalWatcher(LitElement) {
     this.#go3270?.outbound(chars);
   }
 
+  pointer(evt: MouseEvent | WheelEvent): void {
+    const deltaY = evt instanceof WheelEvent ? evt.deltaY : 0;
+    this.#go3270?.pointer(evt.type, evt.offsetX, evt.offsetY, deltaY);
+    evt.preventDefault();
+  }
+
   override render(): TemplateResult {
     return html`
       <main class="stretcher">
@@ -151,7 +157,12 @@ export class Emulator extends SignalWatcher(LitElement) {
           </header>
 
           <article class="wrapper">
-            <canvas class="terminal" id="terminal"></canvas>
+            <canvas
+              @click=${(evt: MouseEvent): void => this.pointer(evt)}
+              @dblclick=${(evt: MouseEvent): void => this.pointer(evt)}
+              @wheel=${(evt: WheelEvent): void => this.pointer(evt)}
+              class="terminal"
+              id="terminal"></canvas>
           </article>
 
           <footer
@@ -125,6 +125,7 @@ export const defaultConfig: Config = {
 
 export type Status = {
   alarm: boolean;
+  connected: boolean;
   cursorAt: number;
   error: boolean;
   insert: boolean;
@@ -137,6 +138,7 @@ export type Status = {
 
 export const defaultStatus: Status = {
   alarm: false,
+  connected: false,
   cursorAt: 0,
   error: false,
   insert: false,
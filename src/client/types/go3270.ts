@@ -3,8 +3,27 @@ import { CLUT } from '$client/state/state';
 // 🟧 Go <--> Typescript interface
 
 export type Go3270 = {
+  breakpoint: (row: number, col: number, set: boolean) => boolean;
   close: () => void;
+  continue: () => Go3270Step | null;
+  copy: (
+    x1: number,
+    y1: number,
+    x2: number,
+    y2: number,
+    rect: boolean
+  ) => string;
+  cursor: (
+    style: 'BLOCK' | 'UNDERLINE' | 'BAR',
+    steady: boolean,
+    ruler: boolean
+  ) => void;
+  debug: (enabled: boolean) => void;
+  fields: () => Go3270Fld[];
+  fit: (width: number, height: number) => number;
+  font: (font: string | Uint8Array) => string;
   focus: (focus: boolean) => void;
+  inspect: (x: number, y: number) => Go3270Cell | null;
   keystroke: (
     code: string,
     key: string,
@@ -13,6 +32,72 @@ export type Go3270 = {
     shift: boolean
   ) => void;
   outbound: (chars: Uint8ClampedArray) => void;
+  overlay: (show: boolean, reveal?: boolean) => void;
+  paste: (text: string) => void;
+  pointer: (
+    type: string,
+    x: number,
+    y: number,
+    deltaY: number
+  ) => void;
+  profile: (
+    name: string,
+    baseColors?: [number, number, number, number]
+  ) => boolean;
+  resize: (
+    fontSize: number,
+    dpi: number,
+    paddedHeight: number,
+    paddedWidth: number
+  ) => void;
+  step: () => Go3270Step | null;
+};
+
+// 👇 what the inspector knows about a cell and its field
+
+export type Go3270Cell = {
+  addr: number;
+  attrs: string;
+  char: number;
+  charAttr: boolean;
+  col: number;
+  fld: Go3270Fld | null;
+  fldHome: boolean;
+  fldStart: boolean;
+  lcid: number;
+  row: number;
+  rune: string;
+};
+
+export type Go3270Fld = {
+  addr: number;
+  attrs: string;
+  autoskip: boolean;
+  col: number;
+  end: number;
+  hasHome: boolean;
+  home: number;
+  input: boolean;
+  len: number;
+  mdt: boolean;
+  numeric: boolean;
+  row: number;
+  value: string;
+};
+
+// 👇 what the debugger did with a single outbound order
+
+export type Go3270Step = {
+  addr: number;
+  break: boolean;
+  col: number;
+  done: boolean;
+  fldAddr: number;
+  fldAttrs: string;
+  inFld: boolean;
+  order: string;
+  row: number;
+  touched: number[];
 };
 
 declare global {
@@ -27,7 +112,9 @@ declare global {
       rows: number,
       cols: number,
       dpi: number,
-      screenshot: string
+      screenshot: string,
+      oia?: boolean,
+      font?: string
     ) => Go3270;
   }
 }
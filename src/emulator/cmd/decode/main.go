@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/core"
+	"emulator/export"
+	"emulator/tn3270"
+	"emulator/trace"
+	"emulator/types"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// 🟧 Decode captured outbound records offline, list them as the
+//    Logger would and render the screen they leave behind
+
+// 👇 go run ./cmd/decode [-rows 24] [-cols 80] [-cp 037] [-hex | -trace]
+//                        [-png file] [-text] [-inbound] [-color] file
+//    a binary or hex file holds one record, or several each ending
+//    IAC EOR as on the wire; hex may be a dump from xxd, hexdump -C
+//    or od -t x1; a trace brings its own screen size
+
+type options struct {
+	asHex   bool
+	asTrace bool
+	color   bool
+	cols    uint
+	cp      string
+	inbound bool
+	png     string
+	rows    uint
+	text    bool
+}
+
+func main() {
+	var opts options
+	flag.UintVar(&opts.rows, "rows", 24, "screen rows")
+	flag.UintVar(&opts.cols, "cols", 80, "screen cols")
+	flag.StringVar(&opts.cp, "cp", "037", "base code page: 037, 273, 500 or 1140")
+	flag.BoolVar(&opts.asHex, "hex", false, "the file is a hex dump")
+	flag.BoolVar(&opts.asTrace, "trace", false, "the file is a trace")
+	flag.StringVar(&opts.png, "png", "", "render the final screen to this PNG file")
+	flag.BoolVar(&opts.text, "text", false, "print the final screen as text")
+	flag.BoolVar(&opts.inbound, "inbound", false, "list inbound records, then what RB and RM would send")
+	flag.BoolVar(&opts.color, "color", false, "color the listing for a terminal")
+	flag.Parse()
+	if flag.NArg() != 1 || (opts.asHex && opts.asTrace) {
+		fmt.Fprintln(os.Stderr, "usage: decode [-rows 24] [-cols 80] [-cp 037] [-hex | -trace] [-png file] [-text] [-inbound] [-color] file")
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), opts, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+}
+
+func run(path string, opts options, w io.Writer) error {
+	if _, ok := conv.CodePages[opts.cp]; !ok {
+		return fmt.Errorf("unknown code page %s", opts.cp)
+	}
+	records, rows, cols, err := recordsFrom(path, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	// 🔥 the listing is for people, not terminals, unless asked
+	if opts.color {
+		text.EnableColors()
+	} else {
+		text.DisableColors()
+	}
+	emu, err := newEmulator(rows, cols, opts.cp, opts.png != "")
+	if err != nil {
+		return err
+	}
+	l := core.NewListing(emu, w)
+	// 👇 list whatever the emulator answers, as it answers
+	if opts.inbound {
+		emu.Bus.SubInbound(func(chars []byte, hints core.PubInboundHints) {
+			fmt.Fprintf(w, "inbound % x\n", chars)
+			l.ListInbound(chars, hints)
+		})
+	}
+	emu.Initialize()
+	for ix, chars := range records {
+		fmt.Fprintf(w, "record %d: % x\n", ix, chars)
+		l.ListOutbound(chars)
+		emu.Bus.PubOutbound(chars)
+		if err := emu.Out.Err(); err != nil {
+			fmt.Fprintln(w, "🔥", err)
+		}
+	}
+	if opts.inbound {
+		emu.Bus.PubRB(types.INBOUND)
+		emu.Bus.PubRM(types.INBOUND)
+	}
+	if opts.text {
+		fmt.Fprint(w, export.Text(export.NewSnapshot(emu), true))
+	}
+	if opts.png != "" {
+		return writePNG(opts.png, emu.Cfg.RGBA)
+	}
+	return nil
+}
+
+// 🟦 Helpers
+
+// 👇 whitespace, commas and 0x prefixes are all ignored, as are the
+//    offsets and text of a dump from xxd, hexdump -C or od -t x1
+
+func fromHex(str string) ([]byte, error) {
+	lines := strings.Split(str, "\n")
+	for ix, line := range lines {
+		if strings.TrimSpace(line) == "*" {
+			return nil, fmt.Errorf("repeated lines left out of the dump, so dump with -v")
+		}
+		if xxdLine.MatchString(line) {
+			// 👇 the bytes end at the two spaces before the text
+			line = strings.TrimPrefix(line[strings.Index(line, ":")+1:], " ")
+			if end := strings.Index(line, "  "); end >= 0 {
+				line = line[:end]
+			}
+		}
+		lines[ix] = gutter.ReplaceAllString(line, "")
+	}
+	if isDump(lines) {
+		for ix, line := range lines {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				lines[ix] = strings.Join(fields[1:], " ")
+			}
+		}
+	}
+	str = strings.Join(lines, " ")
+	str = strings.ReplaceAll(str, "0x", "")
+	str = strings.ReplaceAll(str, ",", " ")
+	return hex.DecodeString(strings.Join(strings.Fields(str), ""))
+}
+
+var (
+	// 👇 "00000010: f5c2 1140  ..@@" from xxd
+	xxdLine = regexp.MustCompile(`^\s*[0-9a-fA-F]+: `)
+	// 👇 "|..@@|" from hexdump -C, ">..@@<" from od -t x1z
+	gutter = regexp.MustCompile(`\s+(\|.*\||>.*<)\s*$`)
+	// 👇 "0000020 f1 f2 ff ef" from hexdump -C or od, or just an offset
+	offsetLine = regexp.MustCompile(`^\s*([0-9a-fA-F]{6,})((\s+[0-9a-fA-F]{2})*)\s*$`)
+)
+
+// 🔥 bare hex can look like a dump, so the first line must start at
+//    offset 0, each line after at a greater offset, and every offset
+//    be followed by single bytes -- unless it is the length at the end
+
+func isDump(lines []string) bool {
+	offsets, bytes := 0, 0
+	var last uint64
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		m := offsetLine.FindStringSubmatch(line)
+		if m == nil {
+			return false
+		}
+		// 👇 octal or decimal offsets increase in hex too
+		offset, _ := strconv.ParseUint(m[1], 16, 64)
+		if (offsets == 0 && offset != 0) || (offsets > 0 && offset <= last) {
+			return false
+		}
+		last = offset
+		offsets++
+		if m[2] != "" {
+			bytes++
+		}
+	}
+	return bytes > 0 && offsets-bytes <= 1
+}
+
+// 👇 no fonts and no image, unless we are to draw the screen
+func newEmulator(rows, cols uint, cp string, render bool) (*core.Emulator, error) {
+	cfg, err := core.HeadlessConfig(rows, cols, render)
+	if err != nil {
+		return nil, err
+	}
+	cfg.CodePage = cp
+	return core.NewEmulator(core.NewBus(), cfg), nil
+}
+
+// 👇 the outbound records in the file, and the screen size they need
+func recordsFrom(path string, opts options) (records [][]byte, rows, cols uint, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	switch {
+
+	case opts.asHex:
+		chars, err := fromHex(string(raw))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		return split(chars), opts.rows, opts.cols, nil
+
+	case opts.asTrace:
+		t, err := trace.Read(bytes.NewReader(raw))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		for _, evt := range t.Events {
+			if evt.Kind == trace.OUTBOUND {
+				records = append(records, evt.Data)
+			}
+		}
+		return records, t.Header.Rows, t.Header.Cols, nil
+
+	default:
+		return split(raw), opts.rows, opts.cols, nil
+	}
+}
+
+// 👇 records as framed on the wire, or just one if they aren't
+func split(chars []byte) [][]byte {
+	records := make([][]byte, 0)
+	record := make([]byte, 0)
+	framed := false
+	for ix := 0; ix < len(chars); ix++ {
+		// 👇 IAC IAC is data, IAC EOR ends the record
+		if chars[ix] == tn3270.IAC && ix+1 < len(chars) {
+			switch chars[ix+1] {
+
+			case tn3270.IAC:
+				record = append(record, tn3270.IAC)
+				ix++
+				continue
+
+			case tn3270.EOR:
+				records = append(records, record)
+				record = make([]byte, 0)
+				framed = true
+				ix++
+				continue
+
+			}
+		}
+		record = append(record, chars[ix])
+	}
+	if !framed {
+		return [][]byte{chars}
+	}
+	// 👇 anything after the last EOR was cut short by the capture
+	if len(record) > 0 {
+		records = append(records, record)
+	}
+	return records
+}
+
+func writePNG(path string, img *image.RGBA) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
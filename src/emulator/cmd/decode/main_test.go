@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/trace"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	opts := options{cols: 40, cp: "037", rows: 12}
+
+	t.Run("framed binary records", func(t *testing.T) {
+		path := filepath.Join(dir, "records.bin")
+		// 👇 EW "HI" then W "A", each ending IAC EOR
+		os.WriteFile(path, []byte{0xf5, 0xc2, 0xc8, 0xc9, 0xff, 0xef, 0xf1, 0xc2, 0xc1, 0xff, 0xef}, 0644)
+		var b bytes.Buffer
+		assert.NoError(t, run(path, options{cols: 40, cp: "037", rows: 12, text: true}, &b))
+		out := b.String()
+		assert.Contains(t, out, "record 0: f5 c2 c8 c9\n")
+		assert.Contains(t, out, "record 1: f1 c2 c1\n")
+		assert.Contains(t, out, "EW Outbound Orders")
+		assert.Contains(t, out, "HIA"+strings.Repeat(" ", 37)+"\n")
+		assert.NotContains(t, out, "\x1b[", "no colors unless asked")
+	})
+
+	t.Run("a hex record in another code page", func(t *testing.T) {
+		path := filepath.Join(dir, "record.hex")
+		os.WriteFile(path, []byte("0xf5, 0xc2,\n0x11 0x40 0x40\n4a"), 0644)
+		var b bytes.Buffer
+		assert.NoError(t, run(path, options{asHex: true, cols: 40, cp: "273", rows: 12, text: true}, &b))
+		assert.Contains(t, b.String(), "\nÄ ")
+		assert.Equal(t, rune('['), conv.E2Rune(0x00, 0x4a), "no one else is affected")
+	})
+
+	t.Run("a trace, with inbound", func(t *testing.T) {
+		path := filepath.Join(dir, "record.trace")
+		tr := &trace.Trace{
+			Header: trace.Header{Cols: 40, Rows: 12, Version: trace.Version},
+			Events: []trace.Event{
+				{Data: trace.Hex{0xf5, 0xc2, 0x1d, 0x40, 0xc8, 0xc9}, Kind: trace.OUTBOUND},
+				{Data: trace.Hex{0xf1, 0xc2, 0x11, 0x7f, 0x7f}, Kind: trace.OUTBOUND},
+			},
+		}
+		f, _ := os.Create(path)
+		assert.NoError(t, tr.Write(f))
+		f.Close()
+		var b bytes.Buffer
+		assert.NoError(t, run(path, options{asTrace: true, cp: "037", inbound: true}, &b))
+		out := b.String()
+		// 👇 a bad record is reported, but doesn't stop us
+		assert.Contains(t, out, "🔥 core: PROG 752 in W")
+		assert.Contains(t, out, "inbound 88 40 40 1d 00 c8 c9 00")
+		assert.Contains(t, out, "INBOUND: FIELD_MODE Inbound RB")
+		assert.Contains(t, out, "inbound 88 40 40 ff ef\n")
+		assert.Contains(t, out, "INBOUND: FIELD_MODE Inbound RM/RMA")
+	})
+
+	t.Run("render to PNG", func(t *testing.T) {
+		path := filepath.Join(dir, "record.bin")
+		os.WriteFile(path, []byte{0xf5, 0xc2, 0xc8, 0xc9}, 0644)
+		img := filepath.Join(dir, "screen.png")
+		assert.NoError(t, run(path, options{cols: 40, cp: "037", png: img, rows: 12}, &bytes.Buffer{}))
+		f, err := os.Open(img)
+		assert.NoError(t, err)
+		defer f.Close()
+		cfg, err := png.DecodeConfig(f)
+		assert.NoError(t, err)
+		assert.Greater(t, cfg.Width, 40*9)
+	})
+
+	t.Run("bad input is an error", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.hex")
+		os.WriteFile(path, []byte("f5 c"), 0644)
+		assert.Error(t, run(path, options{asHex: true, cp: "037"}, &bytes.Buffer{}))
+		assert.ErrorContains(t, run(path, options{cp: "999"}, &bytes.Buffer{}), "unknown code page 999")
+		assert.Error(t, run(filepath.Join(dir, "missing"), opts, &bytes.Buffer{}))
+	})
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, [][]byte{{0xf1, 0xc2, 0x5a}}, split([]byte{0xf1, 0xc2, 0x5a}), "unframed")
+	// 👇 a doubled IAC is data, even before EOR's byte
+	assert.Equal(t, [][]byte{{0xf1, 0xff, 0xef}, {0xf5}}, split([]byte{0xf1, 0xff, 0xff, 0xef, 0xff, 0xef, 0xf5}))
+	assert.Equal(t, [][]byte{{0xf1, 0xff}}, split([]byte{0xf1, 0xff}))
+}
+
+func TestFromHex(t *testing.T) {
+	want := []byte{0xf5, 0xc2, 0x11, 0x40, 0x40, 0xc1, 0xc2, 0xc3, 0x81, 0x82, 0x83, 0x7c, 0x4b, 0x5b, 0x60, 0xf0, 0xf1, 0xf2, 0xff, 0xef}
+	dumps := map[string]string{
+		"xxd": "" +
+			"00000000: f5c2 1140 40c1 c2c3 8182 837c 4b5b 60f0  ...@@......|K[`.\n" +
+			"00000010: f1f2 ffef                                ....\n",
+		"hexdump -C": "" +
+			"00000000  f5 c2 11 40 40 c1 c2 c3  81 82 83 7c 4b 5b 60 f0  |...@@......|K[`.|\n" +
+			"00000010  f1 f2 ff ef                                       |....|\n" +
+			"00000014\n",
+		"od -A x -t x1z": "" +
+			"000000 f5 c2 11 40 40 c1 c2 c3 81 82 83 7c 4b 5b 60 f0  >...@@......|K[`.<\n" +
+			"000010 f1 f2 ff ef                                      >....<\n" +
+			"000014\n",
+		"od -t x1": "" +
+			"0000000 f5 c2 11 40 40 c1 c2 c3 81 82 83 7c 4b 5b 60 f0\n" +
+			"0000020 f1 f2 ff ef\n" +
+			"0000024\n",
+		"bare": "f5c2114040c1c2c3\n8182837c4b5b60f0\nf1f2ffef\n",
+	}
+	for format, dump := range dumps {
+		got, err := fromHex(dump)
+		assert.NoError(t, err, format)
+		assert.Equal(t, want, got, format)
+	}
+	_, err := fromHex("00000000  40 40 40 40 40 40 40 40  40 40 40 40 40 40 40 40  |@@@@@@@@@@@@@@@@|\n*\n00000020\n")
+	assert.ErrorContains(t, err, "-v")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"emulator/lint"
+	"emulator/trace"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// 🟧 Check outbound records for the mistakes that kill a session
+
+// 👇 go run ./cmd/lint [-rows 24] [-cols 80] [-hex | -trace] file...
+//    a file is one binary record, one hex record, or a trace whose
+//    outbound events are all checked at the trace's screen size;
+//    exits 1 if there are any issues
+
+func main() {
+	rows := flag.Uint("rows", 24, "screen rows")
+	cols := flag.Uint("cols", 80, "screen cols")
+	asHex := flag.Bool("hex", false, "the file is a hex dump of a record")
+	asTrace := flag.Bool("trace", false, "the file is a trace")
+	flag.Parse()
+	if flag.NArg() == 0 || (*asHex && *asTrace) {
+		fmt.Fprintln(os.Stderr, "usage: lint [-rows 24] [-cols 80] [-hex | -trace] file...")
+		os.Exit(2)
+	}
+	clean := true
+	for _, path := range flag.Args() {
+		ok, err := run(path, *rows, *cols, *asHex, *asTrace, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "🔥", err)
+			os.Exit(1)
+		}
+		clean = clean && ok
+	}
+	if !clean {
+		os.Exit(1)
+	}
+}
+
+// 👇 true if there are no issues
+func run(path string, rows, cols uint, asHex, asTrace bool, w io.Writer) (bool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	switch {
+
+	case asHex:
+		chars, err := fromHex(string(raw))
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+		return report(w, path, lint.Record(chars, rows, cols)), nil
+
+	case asTrace:
+		t, err := trace.Read(bytes.NewReader(raw))
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+		clean := true
+		for ix, evt := range t.Events {
+			if evt.Kind == trace.OUTBOUND {
+				issues := lint.Record(evt.Data, t.Header.Rows, t.Header.Cols)
+				clean = report(w, fmt.Sprintf("%s event %d", path, ix), issues) && clean
+			}
+		}
+		return clean, nil
+
+	default:
+		return report(w, path, lint.Record(raw, rows, cols)), nil
+	}
+}
+
+// 👇 whitespace, commas and 0x prefixes are all ignored
+func fromHex(str string) ([]byte, error) {
+	str = strings.ReplaceAll(str, "0x", "")
+	str = strings.ReplaceAll(str, ",", " ")
+	return hex.DecodeString(strings.Join(strings.Fields(str), ""))
+}
+
+func report(w io.Writer, where string, issues []lint.Issue) bool {
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s: %s\n", where, issue)
+	}
+	return len(issues) == 0
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"emulator/trace"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("a binary record", func(t *testing.T) {
+		path := filepath.Join(dir, "record.bin")
+		os.WriteFile(path, []byte{0xf5, 0xc2, 0x11, 0x7f, 0x7f}, 0644)
+		var b bytes.Buffer
+		ok, err := run(path, 24, 80, false, false, &b)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, path+": offset 2: ADDRESS SBA address 4095 outside 24x80 screen\n", b.String())
+	})
+
+	t.Run("a hex record", func(t *testing.T) {
+		path := filepath.Join(dir, "record.hex")
+		os.WriteFile(path, []byte("0xf5, 0xc2,\n0x11 0x40 0x40\nc8c9"), 0644)
+		var b bytes.Buffer
+		ok, err := run(path, 24, 80, true, false, &b)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, b.String())
+	})
+
+	t.Run("a trace", func(t *testing.T) {
+		path := filepath.Join(dir, "record.trace")
+		tr := &trace.Trace{
+			Header: trace.Header{Cols: 40, Rows: 12, Version: trace.Version},
+			Events: []trace.Event{
+				{Data: trace.Hex{0xf5, 0xc2, 0xc8, 0xc9}, Kind: trace.OUTBOUND},
+				{Data: trace.Hex{0x7d, 0x40, 0x40}, Kind: trace.INBOUND},
+				{Data: trace.Hex{0xf1, 0xc2, 0x11, 0x5d, 0x7f}, Kind: trace.OUTBOUND},
+			},
+		}
+		f, _ := os.Create(path)
+		assert.NoError(t, tr.Write(f))
+		f.Close()
+		var b bytes.Buffer
+		ok, err := run(path, 24, 80, false, true, &b)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, path+" event 2: offset 2: ADDRESS SBA address 1919 outside 12x40 screen\n", b.String())
+	})
+
+	t.Run("bad hex is an error", func(t *testing.T) {
+		path := filepath.Join(dir, "bad.hex")
+		os.WriteFile(path, []byte("f5 c"), 0644)
+		_, err := run(path, 24, 80, true, false, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"emulator/pcap"
+	"emulator/trace"
+	"emulator/utils"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 🟧 Turn a Wireshark or tcpdump capture into trace files, or into
+//    snapshot source like snapshots/splash.go
+
+// 👇 go run ./cmd/pcap [-port 0] [-go] [-o dir] [-name name] capture
+//    -port 0 takes the lower port of each connection as the host,
+//    trace files load at runtime via $GO3270_SNAPSHOTS, and Go
+//    files must be added to snapshots.Index by hand
+
+func main() {
+	port := flag.Uint("port", 0, "the host's port, or 0 for the lower port")
+	asGo := flag.Bool("go", false, "write Go snapshot source, not trace files")
+	dir := flag.String("o", ".", "directory to write to")
+	name := flag.String("name", "", "name of the output, if not the capture's")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: pcap [-port 0] [-go] [-o dir] [-name name] capture")
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	if *name == "" {
+		*name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	files, err := run(path, uint16(*port), *asGo, *dir, *name)
+	for _, file := range files {
+		fmt.Println(file)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+}
+
+// 👇 the files written
+func run(path string, port uint16, asGo bool, dir, name string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	traces, err := pcap.Import(f, port)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0)
+	for ix, t := range traces {
+		nm := name
+		if len(traces) > 1 {
+			nm = fmt.Sprintf("%s-c%d", name, ix+1)
+		}
+		var written []string
+		if asGo {
+			written, err = writeGo(t, dir, nm)
+		} else {
+			written, err = writeTrace(t, dir, nm)
+		}
+		files = append(files, written...)
+		if err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
+
+// 🟦 Helpers
+
+// 👇 one file per outbound record, named as for snapshots.Add
+func writeGo(t *trace.Trace, dir, name string) ([]string, error) {
+	streams := make([][]byte, 0)
+	for _, evt := range t.Events {
+		if evt.Kind == trace.OUTBOUND {
+			streams = append(streams, evt.Data)
+		}
+	}
+	files := make([]string, 0, len(streams))
+	for ix, stream := range streams {
+		nm := name
+		if len(streams) > 1 {
+			nm = fmt.Sprintf("%s-%d", name, ix+1)
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "package snapshots\n\nvar %s = []byte{", identOf(nm))
+		for jx, char := range stream {
+			fmt.Fprintf(&b, "%s%#x", utils.Ternary(jx == 0, "", ", "), char)
+		}
+		b.WriteString("}\n")
+		file := filepath.Join(dir, nm+".go")
+		if err := os.WriteFile(file, []byte(b.String()), 0644); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func writeTrace(t *trace.Trace, dir, name string) ([]string, error) {
+	file := filepath.Join(dir, name+".trace")
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return []string{file}, t.Write(f)
+}
+
+// 👇 rfe-menu becomes RFE_MENU, as in the hand-made snapshots
+func identOf(name string) string {
+	ident := strings.Map(func(r rune) rune {
+		switch {
+
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+
+		}
+		return '_'
+	}, name)
+	if ident == "" || (ident[0] >= '0' && ident[0] <= '9') {
+		ident = "S_" + ident
+	}
+	return ident
+}
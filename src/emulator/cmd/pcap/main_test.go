@@ -0,0 +1,43 @@
+package main
+
+import (
+	"emulator/trace"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mockTrace = &trace.Trace{
+	Header: trace.Header{Cols: 80, Rows: 24, Source: trace.SOURCE_PCAP, Version: trace.Version},
+	Events: []trace.Event{
+		{Data: trace.Hex{0xf5, 0xc2}, Kind: trace.OUTBOUND},
+		{Data: trace.Hex{0x7d}, Kind: trace.INBOUND},
+		{Data: trace.Hex{0xf1, 0x00}, Kind: trace.OUTBOUND},
+	},
+}
+
+func TestWriteGo(t *testing.T) {
+	dir := t.TempDir()
+	files, err := writeGo(mockTrace, dir, "tso-menu")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "tso-menu-1.go"), filepath.Join(dir, "tso-menu-2.go")}, files)
+	src, _ := os.ReadFile(files[1])
+	assert.Equal(t, "package snapshots\n\nvar TSO_MENU_2 = []byte{0xf1, 0x0}\n", string(src))
+}
+
+func TestWriteTrace(t *testing.T) {
+	files, err := writeTrace(mockTrace, t.TempDir(), "capture")
+	assert.NoError(t, err)
+	f, _ := os.Open(files[0])
+	defer f.Close()
+	tr, err := trace.Read(f)
+	assert.NoError(t, err)
+	assert.Equal(t, mockTrace, tr)
+}
+
+func TestIdentOf(t *testing.T) {
+	assert.Equal(t, "RFE_MENU", identOf("rfe-menu"))
+	assert.Equal(t, "S_3270_X", identOf("3270.x"))
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"emulator/export"
+	"emulator/trace"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// 🟧 Replay a trace and report where the emulator's answers differ
+
+// 👇 go run ./cmd/replay [-x3270] [-screen] trace-file
+//    exits 1 if the replay doesn't match the trace
+
+func main() {
+	x3270 := flag.Bool("x3270", false, "the file is an x3270 -trace")
+	screen := flag.Bool("screen", false, "print the screen as the trace left it")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: replay [-x3270] [-screen] trace-file")
+		os.Exit(2)
+	}
+	ok, err := run(flag.Arg(0), *x3270, *screen, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func run(path string, x3270, screen bool, w io.Writer) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	var t *trace.Trace
+	if x3270 {
+		t, err = trace.ImportX3270(f)
+	} else {
+		t, err = trace.Read(f)
+	}
+	if err != nil {
+		return false, err
+	}
+	emu, mismatches, err := trace.Replay(t)
+	if screen && emu != nil {
+		fmt.Fprint(w, export.Text(export.NewSnapshot(emu), true))
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, m := range mismatches {
+		fmt.Fprintf(w, "❌ event %d\n   want % x\n   got  % x\n", m.At, m.Want, m.Got)
+	}
+	fmt.Fprintf(w, "%d events, %d mismatches\n", len(t.Events), len(mismatches))
+	return len(mismatches) == 0, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"emulator/trace"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	tr := &trace.Trace{
+		Header: trace.Header{Cols: 80, Rows: 24, Source: trace.SOURCE_RECORDER, Version: trace.Version},
+		Events: []trace.Event{
+			{Data: trace.Hex{0xf5, 0xc2, 0xc8, 0xc9}, Kind: trace.OUTBOUND},
+			// 👇 nothing provokes this, so it can't match
+			{Data: trace.Hex{0x7d}, Kind: trace.INBOUND},
+		},
+	}
+	f, _ := os.Create(path)
+	assert.NoError(t, tr.Write(f))
+	f.Close()
+	var b bytes.Buffer
+	ok, err := run(path, false, true, &b)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, b.String(), "HI")
+	assert.Contains(t, b.String(), "❌ event 1\n   want 7d\n")
+	assert.Contains(t, b.String(), "2 events, 1 mismatches")
+}
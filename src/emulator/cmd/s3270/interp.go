@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"emulator/session"
+	"emulator/tn3270"
+	"emulator/types"
+	"emulator/utils"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 🟧 Interpret s3270 actions against a headless session
+
+type Interp struct {
+	cols     uint
+	dial     func(ctx context.Context, addr string, opts session.Options) (*session.Session, error)
+	host     string
+	model    string
+	modelNum int
+	rows     uint
+	sess     *session.Session
+	timeout  time.Duration
+}
+
+type action func(i *Interp, args []string) ([]string, error)
+
+// 👇 by lower-case name, as s3270 ignores case
+var actions map[string]action
+
+func init() {
+	actions = map[string]action{
+		"ascii":      ascii,
+		"backspace":  key(types.Keystroke{Code: "Backspace", Key: "Backspace"}),
+		"backtab":    key(types.Keystroke{Code: "Tab", Key: "Tab", SHIFT: true}),
+		"clear":      press(types.CLEAR),
+		"connect":    connect,
+		"delete":     key(types.Keystroke{Code: "Delete", Key: "Delete"}),
+		"disconnect": disconnect,
+		"down":       key(types.Keystroke{Code: "ArrowDown", Key: "ArrowDown"}),
+		"enter":      press(types.ENTER),
+		"eraseeof":   eraseEOF,
+		"home":       key(types.Keystroke{Code: "Home", Key: "Home"}),
+		"insert":     key(types.Keystroke{Code: "Insert", Key: "Insert"}),
+		"left":       key(types.Keystroke{Code: "ArrowLeft", Key: "ArrowLeft"}),
+		"movecursor": moveCursor,
+		"pa":         pa,
+		"pf":         pf,
+		"reset":      reset,
+		"right":      key(types.Keystroke{Code: "ArrowRight", Key: "ArrowRight"}),
+		"string":     str,
+		"tab":        key(types.Keystroke{Code: "Tab", Key: "Tab"}),
+		"up":         key(types.Keystroke{Code: "ArrowUp", Key: "ArrowUp"}),
+		"wait":       wait,
+	}
+}
+
+var errNotConnected = errors.New("not connected")
+
+// 🟦 Constructor
+
+func NewInterp(modelNum int, timeout time.Duration) (*Interp, error) {
+	i := new(Interp)
+	i.dial = session.Connect
+	i.model = fmt.Sprintf("IBM-3278-%d-E", modelNum)
+	i.modelNum = modelNum
+	i.timeout = timeout
+	var ok bool
+	if i.rows, i.cols, ok = tn3270.SizeOf(i.model); !ok {
+		return nil, fmt.Errorf("unknown model %d", modelNum)
+	}
+	return i, nil
+}
+
+// 🟦 Public functions
+
+// 👇 run one action, answering its data, any error and whether to quit
+func (i *Interp) Do(line string) (data []string, quit bool, err error) {
+	name, args, err := parseAction(line)
+	if err != nil || name == "" {
+		return nil, false, err
+	}
+	lname := strings.ToLower(name)
+	if lname == "quit" || lname == "exit" {
+		if i.sess != nil {
+			i.sess.Close()
+		}
+		return nil, true, nil
+	}
+	act, ok := actions[lname]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown action: %s", name)
+	}
+	data, err = act(i, args)
+	return data, false, err
+}
+
+// 👇 the s3270 loop: for each action, data lines, status and ok/error
+func (i *Interp) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		start := time.Now()
+		data, quit, err := i.Do(scanner.Text())
+		if quit {
+			return nil
+		}
+		if err != nil {
+			data = append(data, err.Error())
+		}
+		for _, line := range data {
+			fmt.Fprintf(out, "data: %s\n", line)
+		}
+		fmt.Fprintln(out, i.statusLine(time.Since(start)))
+		fmt.Fprintln(out, utils.Ternary(err == nil, "ok", "error"))
+	}
+	return scanner.Err()
+}
+
+// 🟦 Actions
+
+// 👇 Ascii(), Ascii(len), Ascii(row,col,len) or Ascii(row,col,rows,cols)
+func ascii(i *Interp, args []string) ([]string, error) {
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	nums, err := numsOf(args)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nums) {
+
+	case 0:
+		return strings.Split(strings.TrimSuffix(i.sess.Text(), "\n"), "\n"), nil
+
+	case 1:
+		row, col := i.sess.Cursor()
+		return []string{i.sess.TextAt(row, col, nums[0])}, nil
+
+	case 3:
+		return []string{i.sess.TextAt(nums[0]+1, nums[1]+1, nums[2])}, nil
+
+	case 4:
+		lines := make([]string, 0, nums[2])
+		for row := range nums[2] {
+			lines = append(lines, i.sess.TextAt(nums[0]+row+1, nums[1]+1, nums[3]))
+		}
+		return lines, nil
+
+	}
+	return nil, fmt.Errorf("Ascii takes 0, 1, 3 or 4 arguments")
+}
+
+func connect(i *Interp, args []string) ([]string, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("Connect takes 1 argument")
+	}
+	if i.sess != nil {
+		return nil, fmt.Errorf("already connected")
+	}
+	// 👇 telnet's port, unless told otherwise
+	host := args[0]
+	addr := utils.Ternary(strings.Contains(host, ":"), host, host+":23")
+	ctx, cancel := i.context()
+	defer cancel()
+	sess, err := i.dial(ctx, addr, session.Options{Model: i.model})
+	if err != nil {
+		return nil, err
+	}
+	i.host, i.sess = host, sess
+	return nil, nil
+}
+
+func disconnect(i *Interp, _ []string) ([]string, error) {
+	if i.sess != nil {
+		i.sess.Close()
+		i.host, i.sess = "", nil
+	}
+	return nil, nil
+}
+
+// 👇 erase from the cursor to the end of the field
+func eraseEOF(i *Interp, _ []string) ([]string, error) {
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	row, col := i.sess.Cursor()
+	return nil, i.sess.FillAt(row, col, "")
+}
+
+// 🔥 s3270 rows and columns are zero-based
+func moveCursor(i *Interp, args []string) ([]string, error) {
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	nums, err := numsOf(args)
+	if err != nil || len(nums) != 2 {
+		return nil, fmt.Errorf("MoveCursor takes 2 numeric arguments")
+	}
+	return nil, i.sess.MoveCursor(nums[0]+1, nums[1]+1)
+}
+
+func pa(i *Interp, args []string) ([]string, error) {
+	nums, err := numsOf(args)
+	if err != nil || len(nums) != 1 || nums[0] < 1 || nums[0] > 3 {
+		return nil, fmt.Errorf("PA takes 1 argument, 1 to 3")
+	}
+	return press(types.AIDOf(fmt.Sprintf("F%d", nums[0]), true, false, false))(i, nil)
+}
+
+func pf(i *Interp, args []string) ([]string, error) {
+	nums, err := numsOf(args)
+	if err != nil || len(nums) != 1 || nums[0] < 1 || nums[0] > 24 {
+		return nil, fmt.Errorf("PF takes 1 argument, 1 to 24")
+	}
+	n := nums[0]
+	return press(types.AIDOf(fmt.Sprintf("F%d", utils.Ternary(n > 12, n-12, n)), false, false, n > 12))(i, nil)
+}
+
+func reset(i *Interp, _ []string) ([]string, error) {
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	return nil, i.sess.Reset()
+}
+
+// 👇 type text, where \n is the Enter key
+func str(i *Interp, args []string) ([]string, error) {
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	lines := strings.Split(strings.Join(args, ""), "\n")
+	for ix, line := range lines {
+		if err := i.sess.Type(line); err != nil {
+			return nil, err
+		}
+		if ix < len(lines)-1 {
+			if err := i.sess.Press(types.ENTER); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// 👇 Wait([timeout,] InputField|Output|Unlock|Seconds)
+func wait(i *Interp, args []string) ([]string, error) {
+	timeout := i.timeout
+	if len(args) > 0 {
+		if secs, err := strconv.ParseFloat(args[0], 64); err == nil {
+			timeout = time.Duration(secs * float64(time.Second))
+			args = args[1:]
+		}
+	}
+	what := strings.ToLower(utils.Ternary(len(args) > 0, strings.Join(args, ""), "inputfield"))
+	if what == "seconds" {
+		time.Sleep(timeout)
+		return nil, nil
+	}
+	if i.sess == nil {
+		return nil, errNotConnected
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	defer cancel()
+	var err error
+	switch what {
+
+	case "inputfield":
+		err = i.sess.WaitForInputField(ctx)
+
+	case "output":
+		err = i.sess.WaitForOutput(ctx)
+
+	case "unlock":
+		err = i.sess.WaitUnlock(ctx)
+
+	default:
+		return nil, fmt.Errorf("unknown Wait type: %s", what)
+
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("Wait timed out")
+	}
+	return nil, err
+}
+
+// 🟦 Helpers
+
+func key(k types.Keystroke) action {
+	return func(i *Interp, _ []string) ([]string, error) {
+		if i.sess == nil {
+			return nil, errNotConnected
+		}
+		return nil, i.sess.Keystroke(k)
+	}
+}
+
+func press(aid types.AID) action {
+	return func(i *Interp, _ []string) ([]string, error) {
+		if i.sess == nil {
+			return nil, errNotConnected
+		}
+		return nil, i.sess.Press(aid)
+	}
+}
+
+func (i *Interp) context() (context.Context, context.CancelFunc) {
+	if i.timeout > 0 {
+		return context.WithTimeout(context.Background(), i.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func numsOf(args []string) ([]uint, error) {
+	nums := make([]uint, len(args))
+	for ix, arg := range args {
+		n, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", arg)
+		}
+		nums[ix] = uint(n)
+	}
+	return nums, nil
+}
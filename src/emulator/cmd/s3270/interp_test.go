@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"emulator/conv"
+	"emulator/session"
+	"emulator/tn3270"
+	"emulator/types"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockInterp(t *testing.T) (*Interp, net.Conn) {
+	i, err := NewInterp(2, time.Second)
+	assert.NoError(t, err)
+	host, client := net.Pipe()
+	i.dial = func(_ context.Context, _ string, opts session.Options) (*session.Session, error) {
+		return session.Open(client, opts)
+	}
+	go host.Write(tn3270.MockRecord(types.EW, ""))
+	return i, host
+}
+
+func TestInterp(t *testing.T) {
+	i, host := mockInterp(t)
+	defer host.Close()
+
+	t.Run("not connected", func(t *testing.T) {
+		_, _, err := i.Do("Enter")
+		assert.ErrorIs(t, err, errNotConnected)
+		assert.Equal(t, "U U U N N 2 24 80 0 0 0x0 -", i.statusLine(0))
+	})
+
+	t.Run("unknown actions", func(t *testing.T) {
+		_, _, err := i.Do("Frobnicate(1)")
+		assert.ErrorContains(t, err, "unknown action")
+	})
+
+	t.Run("connect and wait for input", func(t *testing.T) {
+		_, _, err := i.Do("Connect(mainframe)")
+		assert.NoError(t, err)
+		assert.Equal(t, "mainframe", i.host)
+		_, _, err = i.Do("Wait(InputField)")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(i.statusLine(0), "U F U C(mainframe) I 2 24 80 0 7 0x0"), i.statusLine(0))
+	})
+
+	t.Run("read the screen", func(t *testing.T) {
+		data, _, err := i.Do("Ascii(0, 1, 5)")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Name:"}, data)
+		data, _, _ = i.Do("Ascii()")
+		assert.Len(t, data, 24)
+		data, _, _ = i.Do("Ascii(0, 0, 2, 6)")
+		assert.Equal(t, []string{" Name:", strings.Repeat(" ", 6)}, data)
+	})
+
+	t.Run("type and send", func(t *testing.T) {
+		// 🔥 the pipe blocks until the host reads
+		records := make(chan []byte)
+		go func() {
+			record := make([]byte, 0)
+			buf := make([]byte, 256)
+			for !bytes.HasSuffix(record, []byte{tn3270.IAC, tn3270.EOR}) {
+				n, err := host.Read(buf)
+				if err != nil {
+					break
+				}
+				record = append(record, buf[:n]...)
+			}
+			records <- record
+		}()
+		_, _, err := i.Do(`String("Bob\n")`)
+		assert.NoError(t, err)
+		record := <-records
+		assert.Equal(t, byte(types.ENTER), record[0])
+		assert.Contains(t, conv.E2As(string(record)), "Bob")
+	})
+
+	t.Run("cursor movement is zero-based", func(t *testing.T) {
+		_, _, err := i.Do("MoveCursor(2, 3)")
+		assert.NoError(t, err)
+		row, col := i.sess.Cursor()
+		assert.Equal(t, []uint{3, 4}, []uint{row, col})
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		_, quit, err := i.Do("Quit")
+		assert.NoError(t, err)
+		assert.True(t, quit)
+	})
+}
+
+func TestInterpRun(t *testing.T) {
+	i, err := NewInterp(2, time.Second)
+	assert.NoError(t, err)
+	var out bytes.Buffer
+	assert.NoError(t, i.Run(strings.NewReader("Bogus\nWait(0.01, Seconds)\nQuit\nEnter\n"), &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, "data: unknown action: Bogus", lines[0])
+	assert.Equal(t, "error", lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "U U U N N 2 24 80 0 0 0x0 0.0"), lines[3])
+	assert.Equal(t, "ok", lines[4])
+	// 👇 nothing after Quit
+	assert.Len(t, lines, 5)
+}
+
+func TestNewInterp(t *testing.T) {
+	_, err := NewInterp(9, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// 🟧 Speak the s3270 scripting protocol on stdin/stdout, so that
+//    scripts written for s3270 can drive the emulator instead
+
+// 👇 go run ./cmd/s3270 [-model 2] [-timeout 0] [host[:port]]
+
+func main() {
+	model := flag.Int("model", 2, "3278 model: 2, 3, 4 or 5")
+	timeout := flag.Duration("timeout", 0, "connect and Wait timeout, if none given")
+	flag.Parse()
+	i, err := NewInterp(*model, *timeout)
+	if err == nil && flag.NArg() > 0 {
+		_, err = connect(i, flag.Args()[:1])
+	}
+	if err == nil {
+		err = i.Run(os.Stdin, os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 🟧 Parse an s3270 action, like String("hello") or PF(3) or Enter
+
+func parseAction(line string) (name string, args []string, err error) {
+	line = strings.TrimSpace(line)
+	open := strings.IndexByte(line, '(')
+	if open < 0 {
+		// 👇 a bare action may take space-separated arguments
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return "", nil, nil
+		}
+		return fields[0], fields[1:], nil
+	}
+	if !strings.HasSuffix(line, ")") {
+		return "", nil, fmt.Errorf("missing ) in %s", line)
+	}
+	name = strings.TrimSpace(line[:open])
+	args, err = parseArgs(line[open+1 : len(line)-1])
+	return name, args, err
+}
+
+// 🟦 Helpers
+
+var escapes = map[rune]rune{'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t'}
+
+// 👇 comma-separated, each either bare or quoted with C-style escapes
+func parseArgs(str string) ([]string, error) {
+	args := make([]string, 0)
+	var b strings.Builder
+	quoted, escaped, inQuotes := false, false, false
+	flush := func() {
+		arg := b.String()
+		if !quoted {
+			arg = strings.TrimSpace(arg)
+		}
+		args = append(args, arg)
+		b.Reset()
+		quoted = false
+	}
+	for _, r := range str {
+		switch {
+
+		case escaped:
+			if esc, ok := escapes[r]; ok {
+				r = esc
+			}
+			b.WriteRune(r)
+			escaped = false
+
+		case r == '\\' && inQuotes:
+			escaped = true
+
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+
+		case r == ',' && !inQuotes:
+			flush()
+
+		case !inQuotes && quoted:
+			// 🔥 nothing but space may follow a closing quote
+			if r != ' ' {
+				return nil, fmt.Errorf("unexpected %q after quoted argument", r)
+			}
+
+		default:
+			b.WriteRune(r)
+
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in %s", str)
+	}
+	if b.Len() > 0 || quoted || len(args) > 0 {
+		flush()
+	}
+	return args, nil
+}
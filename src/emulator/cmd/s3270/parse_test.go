@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAction(t *testing.T) {
+	t.Run("bare and parenthesized actions", func(t *testing.T) {
+		name, args, err := parseAction("  Enter ")
+		assert.NoError(t, err)
+		assert.Equal(t, "Enter", name)
+		assert.Empty(t, args)
+		name, args, _ = parseAction("MoveCursor(3, 10)")
+		assert.Equal(t, "MoveCursor", name)
+		assert.Equal(t, []string{"3", "10"}, args)
+		name, args, _ = parseAction("Connect localhost:3270")
+		assert.Equal(t, "Connect", name)
+		assert.Equal(t, []string{"localhost:3270"}, args)
+	})
+
+	t.Run("quoted arguments and escapes", func(t *testing.T) {
+		_, args, err := parseAction(`String("a, \"b\"\n", x)`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a, \"b\"\n", "x"}, args)
+	})
+
+	t.Run("blank lines are no action", func(t *testing.T) {
+		name, _, err := parseAction("")
+		assert.NoError(t, err)
+		assert.Empty(t, name)
+	})
+
+	t.Run("malformed actions", func(t *testing.T) {
+		_, _, err := parseAction("String(\"abc\"")
+		assert.Error(t, err)
+		_, _, err = parseAction(`String("abc)`)
+		assert.Error(t, err)
+		_, _, err = parseAction(`String("abc" d)`)
+		assert.Error(t, err)
+	})
+}
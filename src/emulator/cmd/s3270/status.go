@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// 🟧 The s3270 status line, twelve blank-separated fields:
+//    keyboard, formatting, protection, connection, mode, model,
+//    rows, cols, cursor row, cursor col, window ID, elapsed time
+
+func (i *Interp) statusLine(elapsed time.Duration) string {
+	keyboard, formatted, protected := "U", "U", "U"
+	connection, mode := "N", "N"
+	row, col := uint(1), uint(1)
+	if i.sess != nil && i.sess.Err() == nil {
+		stat := i.sess.Status()
+		switch {
+
+		case stat.Error:
+			keyboard = "E"
+
+		case stat.Locked || stat.Waiting:
+			keyboard = "L"
+
+		}
+		if len(i.sess.Fields()) > 0 {
+			formatted = "F"
+		}
+		if stat.Protected {
+			protected = "P"
+		}
+		connection = fmt.Sprintf("C(%s)", i.host)
+		// 👇 until the host sends 3270 data, we're still negotiating
+		mode = map[bool]string{true: "I", false: "P"}[stat.Connected]
+		row, col = i.sess.Cursor()
+	}
+	// 🔥 s3270 reports the cursor zero-based
+	return fmt.Sprintf("%s %s %s %s %s %d %d %d %d %d 0x0 %s",
+		keyboard, formatted, protected, connection, mode,
+		i.modelNum, i.rows, i.cols, row-1, col-1,
+		elapsedOf(elapsed))
+}
+
+// 🟦 Helpers
+
+func elapsedOf(elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", elapsed.Seconds())
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"emulator/server"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// 🟧 Serve the HTTP/JSON API, so that any language can drive
+//    headless sessions
+
+// 👇 go run ./cmd/server [-addr localhost:3271] [-timeout 10s]
+//    curl -d '{"addr":"localhost:3270"}' localhost:3271/sessions
+
+func main() {
+	addr := flag.String("addr", "localhost:3271", "address to listen on")
+	timeout := flag.Duration("timeout", 10*time.Second, "connect and wait timeout, if none given")
+	flag.Parse()
+	srv := server.New(*timeout)
+	defer srv.Close()
+	fmt.Fprintf(os.Stderr, "🐞 listening on %s\n", *addr)
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+}
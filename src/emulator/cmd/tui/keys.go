@@ -0,0 +1,125 @@
+package main
+
+import (
+	"emulator/types"
+	"emulator/utils"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 🟧 Map terminal key sequences to keystrokes, as if from the browser
+
+// 👇 xterm-style sequences, including the DEC "application" variants
+
+var sequences = map[string]types.Keystroke{
+	"\r":      {Code: "Enter", Key: "Enter"},
+	"\n":      {Code: "Enter", Key: "Enter"},
+	"\t":      {Code: "Tab", Key: "Tab"},
+	"\x1b[Z":  {Code: "Tab", Key: "Tab", SHIFT: true},
+	"\x7f":    {Code: "Backspace", Key: "Backspace"},
+	"\x08":    {Code: "Backspace", Key: "Backspace"},
+	"\x1b":    {Code: "Escape", Key: "Escape"},
+	"\x1b[A":  {Code: "ArrowUp", Key: "ArrowUp"},
+	"\x1b[B":  {Code: "ArrowDown", Key: "ArrowDown"},
+	"\x1b[C":  {Code: "ArrowRight", Key: "ArrowRight"},
+	"\x1b[D":  {Code: "ArrowLeft", Key: "ArrowLeft"},
+	"\x1bOA":  {Code: "ArrowUp", Key: "ArrowUp"},
+	"\x1bOB":  {Code: "ArrowDown", Key: "ArrowDown"},
+	"\x1bOC":  {Code: "ArrowRight", Key: "ArrowRight"},
+	"\x1bOD":  {Code: "ArrowLeft", Key: "ArrowLeft"},
+	"\x1b[H":  {Code: "Home", Key: "Home"},
+	"\x1b[F":  {Code: "End", Key: "End"},
+	"\x1bOH":  {Code: "Home", Key: "Home"},
+	"\x1bOF":  {Code: "End", Key: "End"},
+	"\x1b[1~": {Code: "Home", Key: "Home"},
+	"\x1b[2~": {Code: "Insert", Key: "Insert"},
+	"\x1b[3~": {Code: "Delete", Key: "Delete"},
+	"\x1b[4~": {Code: "End", Key: "End"},
+}
+
+// 👇 F1-F4 end in P-S, the rest in a number then ~
+var fkeys = map[string]int{
+	"P": 1, "Q": 2, "R": 3, "S": 4,
+	"15": 5, "17": 6, "18": 7, "19": 8, "20": 9, "21": 10, "23": 11, "24": 12,
+}
+
+// 🔥 Ctrl-] leaves the emulator, as it leaves telnet
+const quit = 0x1d
+
+// 🟦 Public functions
+
+// 👇 split what was read from the terminal into keystrokes
+func keystrokesOf(chars []byte) (keys []types.Keystroke, quitting bool) {
+	str := string(chars)
+	for len(str) > 0 {
+		if str[0] == quit {
+			return keys, true
+		}
+		key, n, ok := keystrokeOf(str)
+		if ok {
+			keys = append(keys, key)
+		}
+		str = str[n:]
+	}
+	return keys, false
+}
+
+// 🟦 Helpers
+
+// 👇 the keystroke at the start of str, and how many bytes it took
+func keystrokeOf(str string) (types.Keystroke, int, bool) {
+	if str[0] != 0x1b {
+		if key, ok := sequences[str[:1]]; ok {
+			return key, 1, true
+		}
+		// 👇 the keyboard only takes ASCII, one byte at a time
+		if str[0] >= ' ' && str[0] < 0x7f {
+			return types.Keystroke{Code: "Key" + strings.ToUpper(str[:1]), Key: str[:1]}, 1, true
+		}
+		return types.Keystroke{}, 1, false
+	}
+	// 👇 ESC, then [ or O, then parameters up to a final byte
+	end := 1
+	if len(str) > 1 && (str[1] == '[' || str[1] == 'O') {
+		end = 2
+		for end < len(str) && (str[end] < 0x40 || str[end] > 0x7e) {
+			end++
+		}
+		end = min(end+1, len(str))
+	}
+	seq := str[:end]
+	if key, ok := sequences[seq]; ok {
+		return key, end, true
+	}
+	// 🔥 unknown sequences are swallowed whole
+	key, ok := fkeyOf(seq)
+	return key, end, ok
+}
+
+// 👇 F1-F12, with any xterm modifier: 2 = shift, 3 = alt, 5 = ctrl
+func fkeyOf(seq string) (types.Keystroke, bool) {
+	if len(seq) < 3 {
+		return types.Keystroke{}, false
+	}
+	final := seq[len(seq)-1:]
+	params := strings.Split(seq[2:len(seq)-1], ";")
+	name := utils.Ternary(final == "~", params[0], final)
+	num, ok := fkeys[name]
+	if !ok {
+		return types.Keystroke{}, false
+	}
+	mods := 0
+	if len(params) == 2 {
+		mods, _ = strconv.Atoi(params[1])
+		mods = max(mods-1, 0)
+	}
+	fkey := fmt.Sprintf("F%d", num)
+	return types.Keystroke{
+		ALT:   mods&2 != 0,
+		Code:  fkey,
+		CTRL:  mods&4 != 0,
+		Key:   fkey,
+		SHIFT: mods&1 != 0,
+	}, true
+}
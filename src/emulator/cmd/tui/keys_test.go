@@ -0,0 +1,50 @@
+package main
+
+import (
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystrokesOf(t *testing.T) {
+	t.Run("typed characters and control keys", func(t *testing.T) {
+		keys, quitting := keystrokesOf([]byte("ab\t\r"))
+		assert.False(t, quitting)
+		assert.Equal(t, []string{"a", "b", "Tab", "Enter"}, []string{keys[0].Key, keys[1].Key, keys[2].Key, keys[3].Key})
+	})
+
+	t.Run("escape sequences", func(t *testing.T) {
+		keys, _ := keystrokesOf([]byte("\x1b[A\x1bOD\x1b[3~\x1b[Z"))
+		assert.Equal(t, "ArrowUp", keys[0].Code)
+		assert.Equal(t, "ArrowLeft", keys[1].Code)
+		assert.Equal(t, "Delete", keys[2].Code)
+		assert.Equal(t, types.Keystroke{Code: "Tab", Key: "Tab", SHIFT: true}, keys[3])
+	})
+
+	t.Run("function keys map to AIDs", func(t *testing.T) {
+		aidOf := func(seq string) types.AID {
+			keys, _ := keystrokesOf([]byte(seq))
+			assert.Len(t, keys, 1, seq)
+			return types.AIDOf(keys[0].Key, keys[0].ALT, keys[0].CTRL, keys[0].SHIFT)
+		}
+		assert.Equal(t, types.PF1, aidOf("\x1bOP"))
+		assert.Equal(t, types.PF12, aidOf("\x1b[24~"))
+		assert.Equal(t, types.PF15, aidOf("\x1b[1;2R"))
+		assert.Equal(t, types.PF17, aidOf("\x1b[15;2~"))
+		assert.Equal(t, types.PA2, aidOf("\x1b[1;3Q"))
+		assert.Equal(t, types.CLEAR, aidOf("\x1b"))
+	})
+
+	t.Run("unknown sequences are ignored", func(t *testing.T) {
+		keys, _ := keystrokesOf([]byte("\x1b[99;9zx"))
+		assert.Len(t, keys, 1)
+		assert.Equal(t, "x", keys[0].Key)
+	})
+
+	t.Run("Ctrl-] quits", func(t *testing.T) {
+		keys, quitting := keystrokesOf([]byte("a\x1db"))
+		assert.True(t, quitting)
+		assert.Len(t, keys, 1)
+	})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"emulator/core"
+	"emulator/tn3270"
+	"emulator/types"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// 🟧 Run the emulator in a text terminal, like c3270
+
+// 👇 go run ./cmd/tui [-model 2] [-profile 3279] [host[:port]]
+//    Ctrl-] quits, Esc is CLEAR, Alt-F1..F3 are PA1..PA3 and
+//    Shift-F1..F12 are PF13..PF24
+
+func main() {
+	model := flag.Int("model", 2, "3278 model: 2, 3, 4 or 5")
+	profile := flag.String("profile", "3279", "display profile")
+	timeout := flag.Duration("timeout", 10*time.Second, "connection timeout")
+	flag.Parse()
+	addr := "localhost:3270"
+	if flag.NArg() > 0 {
+		addr = flag.Arg(0)
+	}
+	if err := run(addr, fmt.Sprintf("IBM-3278-%d-E", *model), *profile, *timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "🔥", err)
+		os.Exit(1)
+	}
+}
+
+func run(addr, model, profile string, timeout time.Duration) error {
+	rows, cols, ok := tn3270.SizeOf(model)
+	if !ok {
+		return fmt.Errorf("unknown model %s", model)
+	}
+	p, ok := types.ProfileOf(profile)
+	if !ok {
+		return fmt.Errorf("unknown profile %s", profile)
+	}
+	conn, err := tn3270.Dial(addr, model, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	term, err := NewTerminal()
+	if err != nil {
+		return err
+	}
+	defer term.Restore()
+	if trows, tcols := term.Size(); trows > 0 && (trows <= rows || tcols < cols) {
+		return fmt.Errorf("%s needs a %dx%d terminal", model, cols, rows+1)
+	}
+	// 🔥 must subscribe BEFORE we create the emulator
+	bus := core.NewBus()
+	errs := make(chan error, 3)
+	bus.SubInbound(func(chars []byte, _ core.PubInboundHints) {
+		if err := conn.Write(chars); err != nil {
+			errs <- err
+		}
+	})
+	bus.SubPanic(func(msg string) {
+		errs <- fmt.Errorf("%s", msg)
+	})
+	// 👇 only rendering can fail
+	cfg, _ := core.HeadlessConfig(rows, cols, false)
+	cfg.ApplyProfile(p)
+	emu := core.NewEmulator(bus, cfg).Initialize()
+	// 👇 the host and the keyboard each get a goroutine, but only
+	//    this one ever touches the emulator
+	records := make(chan []byte)
+	go func() {
+		for {
+			record, err := conn.Read()
+			if err != nil {
+				errs <- fmt.Errorf("disconnected from %s: %w", addr, err)
+				return
+			}
+			records <- record
+		}
+	}()
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				errs <- err
+				return
+			}
+			input <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+	fmt.Print(draw(emu))
+	for {
+		select {
+
+		case record := <-records:
+			bus.PubOutbound(record)
+
+		case chars := <-input:
+			keys, quitting := keystrokesOf(chars)
+			if quitting {
+				return nil
+			}
+			for _, key := range keys {
+				bus.PubKeystroke(key)
+			}
+
+		case err := <-errs:
+			return err
+
+		}
+		fmt.Print(draw(emu))
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"emulator/core"
+	"emulator/export"
+	"fmt"
+	"strings"
+)
+
+// 🟧 Draw the emulator's screen, with a status line below it
+
+// 👇 the whole screen is redrawn each time, as ANSI is cheap and
+//    terminal emulators are good at not flickering
+
+func draw(emu *core.Emulator) string {
+	var b strings.Builder
+	// 👇 hide the cursor and go home
+	b.WriteString("\x1b[?25l\x1b[H")
+	screen := export.ANSI(export.NewSnapshot(emu))
+	b.WriteString(strings.ReplaceAll(screen, "\n", "\r\n"))
+	b.WriteString("\x1b[0;7m")
+	b.WriteString(statusLine(emu))
+	b.WriteString("\x1b[0m")
+	// 👇 now put the cursor where the emulator has it
+	row, col := emu.Cfg.Addr2RC(emu.State.Status.CursorAt)
+	fmt.Fprintf(&b, "\x1b[%d;%dH\x1b[?25h", row, col)
+	return b.String()
+}
+
+// 👇 the same indicators as the OIA, in plain text
+func statusLine(emu *core.Emulator) string {
+	stat := emu.State.Status
+	cols := int(emu.Cfg.Cols)
+	line := []rune(strings.Repeat(" ", cols))
+	put := func(col int, str string) {
+		for ix, r := range str {
+			if col+ix >= 0 && col+ix < cols {
+				line[col+ix] = r
+			}
+		}
+	}
+	// 👇 readiness and connection to the host
+	put(0, "4")
+	put(1, map[bool]string{true: "A", false: "?"}[stat.Connected])
+	// 👇 input inhibited
+	switch {
+
+	case stat.Waiting:
+		put(8, "X WAIT")
+
+	case stat.Error:
+		put(8, "X "+stat.Message)
+
+	case stat.Locked:
+		put(8, "X SYSTEM")
+
+	}
+	// 👇 keyboard state, anchored to the right
+	if stat.Insert {
+		put(cols-22, "INS")
+	}
+	if stat.Numeric {
+		put(cols-18, "NUM")
+	}
+	if stat.Protected {
+		put(cols-13, "PROT")
+	}
+	r, c := emu.Cfg.Addr2RC(stat.CursorAt)
+	put(cols-7, fmt.Sprintf("%03d/%03d", r, c))
+	return string(line)
+}
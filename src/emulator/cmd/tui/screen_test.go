@@ -0,0 +1,32 @@
+package main
+
+import (
+	"emulator/core"
+	"emulator/types"
+	"emulator/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusLine(t *testing.T) {
+	emu := core.MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(core.MockStream(types.EW, types.WCC{Unlock: true}, core.MockExampleImg, core.MockAttrsMap{}))
+	emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 22)), Insert: utils.BoolPtr(true)})
+	line := statusLine(emu)
+	assert.Len(t, line, 40)
+	assert.True(t, strings.HasPrefix(line, "4A"))
+	assert.Contains(t, line, "INS")
+	assert.True(t, strings.HasSuffix(line, "003/022"))
+}
+
+func TestDraw(t *testing.T) {
+	emu := core.MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(core.MockStream(types.EW, types.WCC{Unlock: true}, core.MockExampleImg, core.MockAttrsMap{}))
+	emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 22))})
+	screen := draw(emu)
+	// 👇 raw mode needs carriage returns
+	assert.Equal(t, 12, strings.Count(screen, "\r\n"))
+	assert.True(t, strings.HasSuffix(screen, "\x1b[3;22H\x1b[?25h"))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// 🟧 Just enough terminal control to run full screen
+
+// 🔥 we shell out to stty rather than take a dependency on
+//    a terminal library for the sake of two ioctls
+
+type Terminal struct {
+	saved string
+}
+
+// 🟦 Constructor
+
+func NewTerminal() (*Terminal, error) {
+	t := new(Terminal)
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("not a terminal: %w", err)
+	}
+	t.saved = strings.TrimSpace(saved)
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, err
+	}
+	// 👇 alternate screen, so the shell's screen comes back afterwards
+	fmt.Print("\x1b[?1049h\x1b[2J")
+	return t, nil
+}
+
+// 🟦 Public functions
+
+func (t *Terminal) Restore() {
+	fmt.Print("\x1b[0m\x1b[?25h\x1b[?1049l")
+	stty(t.saved)
+}
+
+// 👇 rows and columns, as the terminal reports them
+func (t *Terminal) Size() (rows, cols uint) {
+	size, err := stty("size")
+	if err == nil {
+		fmt.Sscanf(size, "%d %d", &rows, &cols)
+	}
+	return rows, cols
+}
+
+// 🟦 Helpers
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
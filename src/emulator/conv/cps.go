@@ -14,11 +14,32 @@ var CPs = map[types.LCID][]rune{
 	0xf1: cps.CP310,
 }
 
+// 👇 base code pages that may replace CP 037 for LCID 0x00
+
+// 🔥 CP 273, 500 and 1140 are generated from the Unicode mappings,
+//    but CP 037 is a copy of the EBCDIC/ASCII conversion that the
+//    keyboard uses, so it only agrees with the Unicode mapping for
+//    the invariant characters -- eg: 0x4a is [ not ¢, and 0x42 is ¡
+//    not â -- and switching from it changes more than the national
+//    characters
+
+var CodePages = map[string][]rune{
+	"037":  cps.CP037,
+	"1140": cps.CP1140,
+	"273":  cps.CP273,
+	"500":  cps.CP500,
+}
+
 // 🟦 Public functions
 
 func E2Rune(lcid types.LCID, e byte) rune {
 	if e >= 64 {
-		return CPs[lcid][e-64]
+		cp, ok := CPs[lcid]
+		// 🔥 an unknown LCID is drawn as if it were the base code page
+		if !ok {
+			cp = cps.CP037
+		}
+		return cp[e-64]
 	} else {
 		return '\u0020'
 	}
@@ -32,3 +53,28 @@ func E2Runes(lcid types.LCID, str string) string {
 	}
 	return string(runes)
 }
+
+// 👇 as E2Rune, but LCID 0x00 is drawn in a base code page
+func E2RuneCP(cp string, lcid types.LCID, e byte) rune {
+	if runes, ok := CodePages[cp]; ok && lcid == 0x00 && e >= 64 {
+		return runes[e-64]
+	}
+	return E2Rune(lcid, e)
+}
+
+// 👇 the reverse of E2RuneCP for LCID 0x00, if the rune is there at all
+
+// 🔥 several characters are drawn as blanks, but 0x40 comes first
+
+func Rune2ECP(cp string, r rune) (byte, bool) {
+	runes, ok := CodePages[cp]
+	if !ok {
+		runes = cps.CP037
+	}
+	for ix, rr := range runes {
+		if rr == r {
+			return byte(ix + 64), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,256 @@
+package cps
+
+// 🟧 CP 273 (Austria/Germany)
+
+// 👇 generated from the Unicode mapping, with controls blank
+
+var CP273 = []rune{
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	// start on line 64 to make reconciliation easier
+	0x20,
+	0xa0,
+	0xe2,
+	0x7b,
+	0xe0,
+	0xe1,
+	0xe3,
+	0xe5,
+	0xe7,
+	0xf1,
+	0xc4,
+	0x2e,
+	0x3c,
+	0x28,
+	0x2b,
+	0x21,
+	0x26,
+	0xe9,
+	0xea,
+	0xeb,
+	0xe8,
+	0xed,
+	0xee,
+	0xef,
+	0xec,
+	0x7e,
+	0xdc,
+	0x24,
+	0x2a,
+	0x29,
+	0x3b,
+	0x5e,
+	0x2d,
+	0x2f,
+	0xc2,
+	0x5b,
+	0xc0,
+	0xc1,
+	0xc3,
+	0xc5,
+	0xc7,
+	0xd1,
+	0xf6,
+	0x2c,
+	0x25,
+	0x5f,
+	0x3e,
+	0x3f,
+	0xf8,
+	0xc9,
+	0xca,
+	0xcb,
+	0xc8,
+	0xcd,
+	0xce,
+	0xcf,
+	0xcc,
+	0x60,
+	0x3a,
+	0x23,
+	0xa7,
+	0x27,
+	0x3d,
+	0x22,
+	0xd8,
+	0x61,
+	0x62,
+	0x63,
+	0x64,
+	0x65,
+	0x66,
+	0x67,
+	0x68,
+	0x69,
+	0xab,
+	0xbb,
+	0xf0,
+	0xfd,
+	0xfe,
+	0xb1,
+	0xb0,
+	0x6a,
+	0x6b,
+	0x6c,
+	0x6d,
+	0x6e,
+	0x6f,
+	0x70,
+	0x71,
+	0x72,
+	0xaa,
+	0xba,
+	0xe6,
+	0xb8,
+	0xc6,
+	0xa4,
+	0xb5,
+	0xdf,
+	0x73,
+	0x74,
+	0x75,
+	0x76,
+	0x77,
+	0x78,
+	0x79,
+	0x7a,
+	0xa1,
+	0xbf,
+	0xd0,
+	0xdd,
+	0xde,
+	0xae,
+	0xa2,
+	0xa3,
+	0xa5,
+	0xb7,
+	0xa9,
+	0x40,
+	0xb6,
+	0xbc,
+	0xbd,
+	0xbe,
+	0xac,
+	0x7c,
+	0x203e,
+	0xa8,
+	0xb4,
+	0xd7,
+	0xe4,
+	0x41,
+	0x42,
+	0x43,
+	0x44,
+	0x45,
+	0x46,
+	0x47,
+	0x48,
+	0x49,
+	0xad,
+	0xf4,
+	0xa6,
+	0xf2,
+	0xf3,
+	0xf5,
+	0xfc,
+	0x4a,
+	0x4b,
+	0x4c,
+	0x4d,
+	0x4e,
+	0x4f,
+	0x50,
+	0x51,
+	0x52,
+	0xb9,
+	0xfb,
+	0x7d,
+	0xf9,
+	0xfa,
+	0xff,
+	0xd6,
+	0xf7,
+	0x53,
+	0x54,
+	0x55,
+	0x56,
+	0x57,
+	0x58,
+	0x59,
+	0x5a,
+	0xb2,
+	0xd4,
+	0x5c,
+	0xd2,
+	0xd3,
+	0xd5,
+	0x30,
+	0x31,
+	0x32,
+	0x33,
+	0x34,
+	0x35,
+	0x36,
+	0x37,
+	0x38,
+	0x39,
+	0xb3,
+	0xdb,
+	0x5d,
+	0xd9,
+	0xda,
+	0x20,
+}
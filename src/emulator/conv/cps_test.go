@@ -27,3 +27,38 @@ func TestE2RunesCP310(t *testing.T) {
 	abcde := E2Runes(0xf1, string([]byte{65, 66, 67, 68, 69}))
 	assert.Equal(t, abcde, "ABCDE", "convert EBCDIC string to runes")
 }
+
+func TestE2RuneCP(t *testing.T) {
+	assert.Equal(t, rune('€'), E2RuneCP("1140", 0x00, 0x9f), "CP 1140 has the euro")
+	assert.Equal(t, rune('Ä'), E2RuneCP("273", 0x00, 0x4a))
+	assert.Equal(t, rune('\u0020'), E2RuneCP("273", 0x00, 0x21), "everything below 0x40 is blank")
+	assert.Equal(t, E2Rune(0xf1, 0x80), E2RuneCP("273", 0xf1, 0x80), "only LCID 0x00 is affected")
+	assert.Equal(t, E2Rune(0x00, 0x4a), E2RuneCP("", 0x00, 0x4a), "CP 037 by default")
+	// 👇 nothing global has changed
+	assert.Equal(t, rune('['), E2Rune(0x00, 0x4a), "CP 037 is not the Unicode mapping")
+}
+
+func TestRune2ECP(t *testing.T) {
+	for _, cp := range []string{"", "037", "1140", "273", "500"} {
+		for e := 0x40; e <= 0xff; e++ {
+			r := E2RuneCP(cp, 0x00, byte(e))
+			got, ok := Rune2ECP(cp, r)
+			assert.True(t, ok)
+			assert.Equal(t, r, E2RuneCP(cp, 0x00, got), "CP %s round trips 0x%02x", cp, e)
+		}
+	}
+	got, _ := Rune2ECP("", 'A')
+	assert.Equal(t, A2E('A'), got, "CP 037 agrees with the keyboard")
+	got, _ = Rune2ECP("", ' ')
+	assert.Equal(t, byte(0x40), got)
+	got, _ = Rune2ECP("273", 'Ä')
+	assert.Equal(t, byte(0x4a), got)
+	_, ok := Rune2ECP("", '€')
+	assert.False(t, ok, "CP 037 has no euro")
+	_, ok = Rune2ECP("", '\x07')
+	assert.False(t, ok, "nor any control characters")
+}
+
+func TestE2RuneUnknownLCID(t *testing.T) {
+	assert.Equal(t, E2Rune(0x00, 0xc1), E2Rune(0x42, 0xc1), "fall back to CP 037")
+}
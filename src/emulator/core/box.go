@@ -23,11 +23,14 @@ func NewBox(row, col uint, cfg *types.Config) Box {
 	h := math.Round(cfg.FontHeight * cfg.PaddedHeight)
 	x := math.Round(float64(col-1) * w)
 	y := math.Round(float64(row-1) * h)
-	// TODO 🔥 we could do better calculating the baseline
-	// this is just a WAG, because an em is drawn with a
-	// significantly different height than that returned
-	// by MeasureString()
+	// 👇 center the font's ascent + descent in the padded box
 	baseline := y + h - (cfg.FontSize / 2)
+	if cfg.NormalFace != nil {
+		metrics := (*cfg.NormalFace).Metrics()
+		ascent := float64(metrics.Ascent) / 64
+		descent := float64(metrics.Descent) / 64
+		baseline = y + math.Round((h-(ascent+descent))/2+ascent)
+	}
 	return Box{x, y, w, h, baseline}
 }
 
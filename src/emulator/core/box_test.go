@@ -21,3 +21,12 @@ func TestNewBox(t *testing.T) {
 	assert.Equal(t, 10.0, box.W)
 	assert.Equal(t, 24.0, box.H)
 }
+
+func TestNewBoxBaseline(t *testing.T) {
+	emu := MockEmulator(12, 40)
+	box := NewBox(5, 10, emu.Cfg)
+	metrics := (*emu.Cfg.NormalFace).Metrics()
+	// 👇 descenders must fit inside the box
+	assert.LessOrEqual(t, box.Baseline+float64(metrics.Descent.Ceil()), box.Y+box.H)
+	assert.GreaterOrEqual(t, box.Baseline-float64(metrics.Ascent.Ceil()), box.Y-1)
+}
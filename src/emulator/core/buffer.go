@@ -13,6 +13,10 @@ type Buffer struct {
 	addr uint
 	buf  []*Cell
 	mode types.Mode
+	// 👇 addresses changed since the screen was last rendered
+	touched    []uint
+	touchedAll bool
+	touches    []bool
 
 	emu *Emulator // 👈 back pointer to all common components
 }
@@ -35,6 +39,9 @@ func (b *Buffer) initialize() {
 func (b *Buffer) reset() {
 	b.buf = make([]*Cell, b.emu.Cfg.Cols*b.emu.Cfg.Rows)
 	b.mode = types.FIELD_MODE
+	b.touched = make([]uint, 0)
+	b.touchedAll = true
+	b.touches = make([]bool, len(b.buf))
 }
 
 // 🟦 Low-level functions
@@ -74,6 +81,7 @@ func (b *Buffer) Replace(cell *Cell, addr uint) (*Cell, bool) {
 		return nil, false
 	}
 	b.buf[addr] = cell
+	b.Touch(addr)
 	return b.buf[addr], true
 }
 
@@ -142,6 +150,38 @@ func (b *Buffer) MustSeek(addr uint) uint {
 	return addr
 }
 
+// 🟦 Touch functions
+
+//    Touch() record that the cell at an address has changed
+//    Touched() addresses changed, or all if the layout may have changed
+//    Untouch() forget all changes, once rendered
+
+func (b *Buffer) Touch(addr uint) {
+	if addr < uint(len(b.touches)) {
+		if !b.touches[addr] {
+			b.touched = append(b.touched, addr)
+			b.touches[addr] = true
+		}
+		// 🔥 a new field can change the attributes of every cell
+		//    up to the next field, so assume everything changed
+		if cell := b.buf[addr]; cell != nil && cell.IsFldStart() {
+			b.touchedAll = true
+		}
+	}
+}
+
+func (b *Buffer) Touched() ([]uint, bool) {
+	return b.touched, b.touchedAll
+}
+
+func (b *Buffer) Untouch() {
+	for _, addr := range b.touched {
+		b.touches[addr] = false
+	}
+	b.touched = b.touched[:0]
+	b.touchedAll = false
+}
+
 // 🟦 Get functions
 
 //    Get() cell at current address, no side effects
@@ -180,6 +220,7 @@ func (b *Buffer) PrevGet() (*Cell, uint) {
 
 func (b *Buffer) Set(cell *Cell) uint {
 	b.buf[b.addr] = cell
+	b.Touch(b.addr)
 	return b.addr
 }
 
@@ -131,3 +131,22 @@ func TestBufferSet(t *testing.T) {
 	addr = emu.Buf.Addr()
 	assert.Equal(t, uint(12*40-1), addr)
 }
+
+func TestBufferTouch(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	_, all := emu.Buf.Touched()
+	assert.True(t, all, "everything touched after reset")
+
+	emu.Buf.Untouch()
+	emu.Buf.Replace(NewCell(emu), 10)
+	emu.Buf.Replace(NewCell(emu), 10)
+	emu.Buf.Seek(20)
+	emu.Buf.Set(NewCell(emu))
+	touched, all := emu.Buf.Touched()
+	assert.False(t, all)
+	assert.Equal(t, []uint{10, 20}, touched, "each address only once")
+
+	emu.Buf.Untouch()
+	touched, _ = emu.Buf.Touched()
+	assert.Empty(t, touched)
+}
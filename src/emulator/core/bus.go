@@ -19,19 +19,24 @@ type Topic int
 const (
 	attn Topic = iota
 	close
+	cursor
 	focus
 	keystroke
 	inbound
 	initialize
 	outbound
 	panic
+	paste
+	pointer
 	probe
+	profile
 	q
 	ql
 	rb
 	render
 	renderDeltas
 	reset
+	resize
 	rm
 	rma
 	status
@@ -58,6 +63,10 @@ func (b *Bus) PubClose() {
 	b.Publish(close)
 }
 
+func (b *Bus) PubCursor(addr uint) {
+	b.Publish(cursor, addr)
+}
+
 func (b *Bus) PubFocus(focussed bool) {
 	b.Publish(focus, focussed)
 }
@@ -84,10 +93,22 @@ func (b *Bus) PubPanic(msg string) {
 	b.Publish(panic, msg)
 }
 
+func (b *Bus) PubPaste(text string) {
+	b.Publish(paste, text)
+}
+
+func (b *Bus) PubPointer(ptr types.Pointer) {
+	b.Publish(pointer, ptr)
+}
+
 func (b *Bus) PubProbe(addr uint) {
 	b.Publish(probe, addr)
 }
 
+func (b *Bus) PubProfile(p types.Profile) {
+	b.Publish(profile, p)
+}
+
 func (b *Bus) PubQ() {
 	b.Publish(q)
 }
@@ -112,6 +133,10 @@ func (b *Bus) PubReset() {
 	b.Publish(reset)
 }
 
+func (b *Bus) PubResize(geo types.Geometry) {
+	b.Publish(resize, geo)
+}
+
 func (b *Bus) PubRM(aid types.AID) {
 	b.Publish(rm, aid)
 }
@@ -142,6 +167,10 @@ func (b *Bus) SubClose(fn func()) {
 	b.Subscribe(close, fn)
 }
 
+func (b *Bus) SubCursor(fn func(addr uint)) {
+	b.Subscribe(cursor, fn)
+}
+
 func (b *Bus) SubFocus(fn func(focus bool)) {
 	b.Subscribe(focus, fn)
 }
@@ -166,10 +195,22 @@ func (b *Bus) SubPanic(fn func(msg string)) {
 	b.Subscribe(panic, fn)
 }
 
+func (b *Bus) SubPaste(fn func(text string)) {
+	b.Subscribe(paste, fn)
+}
+
+func (b *Bus) SubPointer(fn func(ptr types.Pointer)) {
+	b.Subscribe(pointer, fn)
+}
+
 func (b *Bus) SubProbe(fn func(addr uint)) {
 	b.Subscribe(probe, fn)
 }
 
+func (b *Bus) SubProfile(fn func(p types.Profile)) {
+	b.Subscribe(profile, fn)
+}
+
 func (b *Bus) SubQ(fn func()) {
 	b.Subscribe(q, fn)
 }
@@ -194,6 +235,10 @@ func (b *Bus) SubReset(fn func()) {
 	b.Subscribe(reset, fn)
 }
 
+func (b *Bus) SubResize(fn func(geo types.Geometry)) {
+	b.Subscribe(resize, fn)
+}
+
 func (b *Bus) SubRM(fn func(aid types.AID)) {
 	b.Subscribe(rm, fn)
 }
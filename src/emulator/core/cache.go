@@ -1,77 +1,221 @@
 package core
 
 import (
+	"container/list"
 	"emulator/conv"
+	"emulator/types"
 	"emulator/utils"
 	"image"
-
-	"github.com/fogleman/gg"
+	"image/color"
 )
 
 // 🟧 Cache of glyphs as drawn from the buffer
 
+// 🔥 least recently used glyphs are evicted beyond the warm-up set and more
+const maxGlyphs = 4096
+
 type Cache struct {
-	cache map[Glyph]image.Image
+	cache  map[Glyph]*list.Element
+	lru    *list.List
+	max    int
+	raster *Rasterizer
 
 	emu *Emulator // 👈 back pointer to all common components
 }
 
+type cached struct {
+	g   Glyph
+	img *image.RGBA
+}
+
 // 🟦 Constructor
 
 func NewCache(emu *Emulator) *Cache {
 	c := new(Cache)
 	c.emu = emu
+	c.max = maxGlyphs
+	c.raster = NewRasterizer()
 	// 👇 subscriptions
 	c.emu.Bus.SubInitialize(c.initialize)
-	// 🔥 we never reset the glyph cache!
+	// 🔥 we never reset the glyph cache, unless the colors change!
 	// c.emu.Bus.SubReset(c.reset)
+	c.emu.Bus.SubProfile(c.profile)
+	c.emu.Bus.SubResize(c.resize)
 	return c
 }
 
 func (c *Cache) initialize() {
-	c.cache = make(map[Glyph]image.Image)
+	c.flush()
+	c.Warm()
+}
+
+// 👇 glyphs are drawn with colors not in their key, like the background
+func (c *Cache) profile(_ types.Profile) {
+	c.flush()
+}
+
+// 👇 every glyph and mask is now the wrong size
+func (c *Cache) resize(_ types.Geometry) {
+	c.flush()
+	c.raster.Reset()
 }
 
 // 🟦 Public functions
 
 func (c *Cache) ImageFor(g Glyph, box Box) image.Image {
-	img, ok := c.cache[g]
-	if !ok {
-		// 👇 cache miss: draw the glyph in a temporary context
-		rgba := image.NewRGBA(image.Rect(0, 0, int(box.W), int(box.H)))
-		gc := gg.NewContextForRGBA(rgba)
-		gc.SetFontFace(utils.Ternary(g.Highlight, *c.emu.Cfg.BoldFace, *c.emu.Cfg.NormalFace))
-		// 👇 clear background
-		gc.SetHexColor(utils.Ternary(g.Reverse, g.Color, c.emu.Cfg.BgColor))
-		gc.DrawRectangle(0, 0, box.W, box.H)
-		gc.Fill()
-		// 👇 render the byte
-		gc.SetHexColor(utils.Ternary(g.Reverse, c.emu.Cfg.BgColor, g.Color))
-		gc.DrawString(string(conv.E2Rune(g.LCID, g.Char)), 0, box.Baseline-box.Y)
-		// 👇 lines for outline/underscore
-		if g.Underscore || g.Outline.Bottom {
-			gc.SetLineWidth(1)
-			gc.DrawLine(0, box.H, box.W, box.H)
-			gc.Stroke()
+	if elem, ok := c.cache[g]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*cached).img
+	}
+	img := c.draw(g, box)
+	c.cache[g] = c.lru.PushFront(&cached{g: g, img: img})
+	// 👇 evict the least recently used glyph
+	if c.lru.Len() > c.max {
+		oldest := c.lru.Back()
+		delete(c.cache, oldest.Value.(*cached).g)
+		c.lru.Remove(oldest)
+	}
+	return img
+}
+
+func (c *Cache) Len() int {
+	return c.lru.Len()
+}
+
+// 👇 pre-draw common characters in every color, so busy screens hit
+func (c *Cache) Warm() {
+	if c.emu.Cfg.NormalFace == nil || c.emu.Cfg.BoldFace == nil {
+		return
+	}
+	box := NewBox(1, 1, c.emu.Cfg)
+	colors := make(map[string]bool)
+	for _, color := range c.emu.Cfg.CLUT {
+		colors[color] = true
+	}
+	for color := range colors {
+		for _, char := range warmChars {
+			for _, highlight := range []bool{false, true} {
+				c.ImageFor(Glyph{Char: char, Color: color, Highlight: highlight}, box)
+			}
+		}
+	}
+}
+
+// 🟦 Helpers
+
+// 👇 blank (for nulls and attributes) plus EBCDIC letters, digits, punctuation
+var warmChars = func() []byte {
+	chars := []byte{' '}
+	for _, span := range [][2]byte{
+		{0x40, 0x40}, {0x4a, 0x50}, {0x5a, 0x61}, {0x6a, 0x6f}, {0x79, 0x7f},
+		{0x81, 0x89}, {0x91, 0x99}, {0xa1, 0xa9}, {0xc1, 0xc9}, {0xd1, 0xd9},
+		{0xe2, 0xe9}, {0xf0, 0xf9},
+	} {
+		for char := int(span[0]); char <= int(span[1]); char++ {
+			chars = append(chars, byte(char))
+		}
+	}
+	return chars
+}()
+
+// 👇 compose the glyph from solid rectangles and a colorized mask
+func (c *Cache) draw(g Glyph, box Box) *image.RGBA {
+	w, h := int(box.W), int(box.H)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	face := *c.emu.Cfg.NormalFace
+	if g.Highlight {
+		face = *c.emu.Cfg.BoldFace
+	}
+	// 👇 a block cursor takes the cursor color, if any
+	fg := utils.Ternary(g.Reverse, c.emu.Cfg.BgColor, g.Color)
+	bg := utils.Ternary(g.Reverse, g.Color, c.emu.Cfg.BgColor)
+	if g.CursorBlock && c.emu.Cfg.CursorColor != "" {
+		fg, bg = c.emu.Cfg.BgColor, c.emu.Cfg.CursorColor
+	}
+	// 👇 revealed non-display data is marked by its color
+	if g.Overlay.Hidden {
+		fg = c.emu.Cfg.CLUT[types.PINK]
+	}
+	fgColor := utils.HexColor(fg)
+	// 👇 clear background
+	fill(img, img.Bounds(), utils.HexColor(bg))
+	// 👇 the overlay tints the background by the type of field
+	switch g.Overlay.Tint {
+
+	case TINT_NUMERIC:
+		blend(img, img.Bounds(), utils.HexColor(c.emu.Cfg.CLUT[types.YELLOW]))
+
+	case TINT_PROTECTED:
+		blend(img, img.Bounds(), utils.HexColor(c.emu.Cfg.CLUT[types.BLUE]))
+
+	case TINT_UNPROTECTED:
+		blend(img, img.Bounds(), utils.HexColor(c.emu.Cfg.CLUT[types.GREEN]))
+
+	}
+	// 👇 the crosshair is drawn behind the character
+	if g.Ruler.Row || g.Ruler.Col || g.Ruler.Readout != 0 {
+		rulerColor := utils.HexColor(c.rulerColor())
+		if g.Ruler.Row {
+			fill(img, image.Rect(0, h/2, w, h/2+1), rulerColor)
 		}
-		if g.Outline.Right {
-			gc.SetLineWidth(1)
-			gc.DrawLine(box.W, 0, box.W, box.H)
-			gc.Stroke()
+		if g.Ruler.Col {
+			fill(img, image.Rect(w/2, 0, w/2+1, h), rulerColor)
 		}
-		if g.Outline.Top {
-			gc.SetLineWidth(1)
-			gc.DrawLine(0, 0, box.W, 0)
-			gc.Stroke()
+		if g.Ruler.Readout != 0 {
+			colorize(img, c.raster.MaskFor(face, g.Highlight, g.Ruler.Readout, box), rulerColor)
 		}
-		if g.Outline.Left {
-			gc.SetLineWidth(1)
-			gc.DrawLine(0, 0, 0, box.H)
-			gc.Stroke()
+	}
+	// 👇 render the byte
+	colorize(img, c.raster.MaskFor(face, g.Highlight, conv.E2RuneCP(c.emu.Cfg.CodePage, g.LCID, g.Char), box), fgColor)
+	// 👇 non-block cursor styles are drawn over the character
+	cursorColor := color.Color(fgColor)
+	if c.emu.Cfg.CursorColor != "" {
+		cursorColor = utils.HexColor(c.emu.Cfg.CursorColor)
+	}
+	if g.CursorUnderline {
+		fill(img, image.Rect(0, h-2, w, h), cursorColor)
+	}
+	if g.CursorBar {
+		fill(img, image.Rect(0, 0, 2, h), cursorColor)
+	}
+	// 👇 lines for outline/underscore
+	if g.Underscore || g.Outline.Bottom {
+		fill(img, image.Rect(0, h-1, w, h), fgColor)
+	}
+	if g.Outline.Right {
+		fill(img, image.Rect(w-1, 0, w, h), fgColor)
+	}
+	if g.Outline.Top {
+		fill(img, image.Rect(0, 0, w, 1), fgColor)
+	}
+	if g.Outline.Left {
+		fill(img, image.Rect(0, 0, 1, h), fgColor)
+	}
+	// 👇 overlay marks go over everything else
+	if g.Overlay.FldStart {
+		// 🔥 a modified field is marked as such on its SF
+		mark := utils.Ternary(g.Overlay.MDT, types.RED, types.TURQUOISE)
+		fill(img, image.Rect(w/4, h/3, w-w/4, h-h/3), utils.HexColor(c.emu.Cfg.CLUT[mark]))
+	}
+	if g.Overlay.CharAttr {
+		fill(img, image.Rect(w-3, 0, w, 3), utils.HexColor(c.emu.Cfg.CLUT[types.ORANGE]))
+	}
+	if g.Overlay.Hidden {
+		for x := 0; x < w; x += 2 {
+			fill(img, image.Rect(x, h-1, x+1, h), fgColor)
 		}
-		// 👇 now cache the glyph
-		img = gc.Image()
-		c.cache[g] = img
 	}
 	return img
 }
+
+func (c *Cache) flush() {
+	c.cache = make(map[Glyph]*list.Element)
+	c.lru = list.New()
+}
+
+func (c *Cache) rulerColor() string {
+	if c.emu.Cfg.RulerColor != "" {
+		return c.emu.Cfg.RulerColor
+	}
+	return c.emu.Cfg.CLUT[utils.Ternary(c.emu.Cfg.Monochrome, types.GREEN, types.PURPLE)]
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"image/color"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,3 +33,83 @@ func TestCacheImageFor(t *testing.T) {
 	img3 := emu.GC.ImageFor(g, NewBox(5, 6, emu.Cfg))
 	assert.NotEqual(t, img1, img3, "different glyphs create different images")
 }
+
+func TestCacheLines(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Cfg.CursorColor = "#ff0000"
+	box := NewBox(1, 1, emu.Cfg)
+	w, h := int(box.W), int(box.H)
+	fg := color.RGBA{0x00, 0xff, 0x00, 0xff}
+	g := Glyph{
+		Char:      0x40,
+		Color:     "#00ff00",
+		CursorBar: true,
+		Outline: Outline{
+			Bottom: true,
+			Right:  true,
+			Top:    true,
+			Left:   true,
+		},
+		Underscore: true,
+	}
+	img := emu.GC.ImageFor(g, box)
+	// 👇 underscores and outlines are drawn in the foreground color
+	assert.Equal(t, fg, color.RGBAModel.Convert(img.At(w/2, h-1)), "bottom")
+	assert.Equal(t, fg, color.RGBAModel.Convert(img.At(w-1, h/2)), "right")
+	assert.Equal(t, fg, color.RGBAModel.Convert(img.At(w/2, 0)), "top")
+	assert.Equal(t, fg, color.RGBAModel.Convert(img.At(0, h/2)), "left")
+	// 👇 only the cursor itself takes the cursor color
+	assert.Equal(t, color.RGBA{0xff, 0x00, 0x00, 0xff}, color.RGBAModel.Convert(img.At(1, h/2)))
+}
+
+func TestCacheWarm(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	assert.Positive(t, emu.GC.Len())
+	assert.LessOrEqual(t, emu.GC.Len(), maxGlyphs)
+	// 👇 a common character is already drawn in every color
+	for _, color := range emu.Cfg.CLUT {
+		_, ok := emu.GC.cache[Glyph{Char: 0xc1, Color: color, Highlight: true}]
+		assert.True(t, ok, color)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.GC.flush()
+	emu.GC.max = 2
+	box := NewBox(1, 1, emu.Cfg)
+	a := Glyph{Char: 0xc1, Color: "#ffffff"}
+	b := Glyph{Char: 0xc2, Color: "#ffffff"}
+	c := Glyph{Char: 0xc3, Color: "#ffffff"}
+	emu.GC.ImageFor(a, box)
+	emu.GC.ImageFor(b, box)
+	// 👇 touching "a" makes "b" the least recently used
+	emu.GC.ImageFor(a, box)
+	emu.GC.ImageFor(c, box)
+	assert.Equal(t, 2, emu.GC.Len())
+	assert.Contains(t, emu.GC.cache, a)
+	assert.NotContains(t, emu.GC.cache, b)
+	assert.Contains(t, emu.GC.cache, c)
+}
+
+func TestRasterizerMask(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	box := NewBox(1, 1, emu.Cfg)
+	r := NewRasterizer()
+	mask := r.MaskFor(*emu.Cfg.NormalFace, false, 'A', box)
+	assert.Equal(t, int(box.W), mask.Bounds().Dx())
+	assert.Same(t, mask, r.MaskFor(*emu.Cfg.NormalFace, false, 'A', box), "masks are drawn once")
+	// 👇 a letter has ink, a blank has none
+	ink := func(m interface{ AlphaAt(x, y int) color.Alpha }) bool {
+		for y := 0; y < int(box.H); y++ {
+			for x := 0; x < int(box.W); x++ {
+				if m.AlphaAt(x, y).A != 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	assert.True(t, ink(mask))
+	assert.False(t, ink(r.MaskFor(*emu.Cfg.NormalFace, false, ' ', box)))
+}
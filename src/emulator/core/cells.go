@@ -1,7 +1,9 @@
 package core
 
 import (
+	"emulator/conv"
 	"emulator/types"
+	"strings"
 )
 
 // 🟧 View the buffer as an array of cells
@@ -40,6 +42,37 @@ func (c *Cells) reset() {
 
 // 🟦 Public functions
 
+// 👇 copy a selection as text, either a rectangle with corners at
+//    from and to, or a stream of cells that wraps from line to line
+
+func (c *Cells) Copy(from, to uint, rect bool) string {
+	from, to = min(from, to), max(from, to)
+	frow, fcol := c.emu.Cfg.Addr2RC(from)
+	trow, tcol := c.emu.Cfg.Addr2RC(to)
+	lines := make([]string, 0)
+	for row := frow; row <= trow; row++ {
+		var b strings.Builder
+		start, stop := uint(1), c.emu.Cfg.Cols
+		if rect {
+			start, stop = min(fcol, tcol), max(fcol, tcol)
+		} else {
+			// 👇 the first and last lines of a stream are partial
+			if row == frow {
+				start = fcol
+			}
+			if row == trow {
+				stop = tcol
+			}
+		}
+		for col := start; col <= stop; col++ {
+			cell := c.emu.Buf.MustPeek(c.emu.Cfg.RC2Addr(row, col))
+			b.WriteRune(c.runeOf(cell))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
 // 👁️ Erase Unprotected to Address order pp 4-10 to 4-11
 func (c *Cells) EUA(start, stop uint) {
 	addr := c.emu.Buf.MustSeek(start)
@@ -109,3 +142,13 @@ func (c *Cells) RB() []byte {
 	}
 	return chars
 }
+
+// 🟦 Helpers
+
+// 👇 what the operator sees, so non-display fields are blank
+func (c *Cells) runeOf(cell *Cell) rune {
+	if cell.IsFldStart() || cell.Attrs.Hidden || cell.Char <= 0x40 {
+		return ' '
+	}
+	return conv.E2RuneCP(c.emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char)
+}
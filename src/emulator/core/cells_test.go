@@ -77,3 +77,29 @@ func TestNewCells(t *testing.T) {
 		}
 	})
 }
+
+func TestCellsCopy(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	attrs := MockAttrsMap{
+		{5, 21}: {Hidden: true},
+	}
+	img := append([]string{}, cellsImg...)
+	img[4] = "¶Where are you from?■Secret            ¶"
+	stream := MockStream(types.EW, types.WCC{}, img, attrs)
+	emu.Bus.PubOutbound(stream)
+
+	t.Run("copy a stream selection", func(t *testing.T) {
+		text := emu.Cells.Copy(emu.Cfg.RC2Addr(3, 2), emu.Cfg.RC2Addr(5, 6), false)
+		assert.Equal(t, "What is your name ?\n\n Where", text)
+	})
+
+	t.Run("copy a rectangular selection", func(t *testing.T) {
+		text := emu.Cells.Copy(emu.Cfg.RC2Addr(5, 6), emu.Cfg.RC2Addr(3, 2), true)
+		assert.Equal(t, "What\n\nWhere", text)
+	})
+
+	t.Run("non-display fields are omitted", func(t *testing.T) {
+		text := emu.Cells.Copy(emu.Cfg.RC2Addr(5, 1), emu.Cfg.RC2Addr(5, 40), false)
+		assert.Equal(t, " Where are you from?", text)
+	})
+}
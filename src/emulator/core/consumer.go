@@ -1,7 +1,7 @@
 package core
 
 import (
-	"emulator/conv"
+	"emulator/datastream"
 	"emulator/types"
 	"emulator/utils"
 	"fmt"
@@ -14,6 +14,8 @@ import (
 // https://bitsavers.org/pdf/ibm/3270/GA23-0059-07_3270_Data_Stream_Programmers_Reference_199206.pdf
 
 type Consumer struct {
+	err *ProgCheck
+
 	emu *Emulator // 👈 back pointer to all common components
 }
 
@@ -28,13 +30,31 @@ func NewConsumer(emu *Emulator) *Consumer {
 }
 
 func (c *Consumer) consume(chars []byte) {
-	defer utils.ElapsedTime(time.Now())
-	// 👇 process the commands in the stream
-	out := NewOutbound(chars, c.emu.Bus)
-	char := out.MustNext()
-	cmd := types.Command(char)
-	c.commands(out, cmd)
-	// 👇 once stream is processed we are able to reflect current cell status
+	// 👇 while the debugger is stepping, a stream must wait its turn
+	if c.emu.Dbg.hold(chars) {
+		return
+	}
+	// 🔥 headless front ends may own stdout
+	if !c.emu.Cfg.SuppressLogs {
+		defer utils.ElapsedTime(time.Now())
+	}
+	// 👇 process the command in the stream, unless we must reject it
+	out, err := datastream.DecodeOutbound(chars)
+	c.err = c.check(out, err)
+	if c.err == nil {
+		c.commands(out)
+	} else {
+		c.reject()
+	}
+	// 👇 the debugger reflects the status once the orders are stepped
+	if c.emu.Dbg.Paused() {
+		return
+	}
+	c.reflect()
+}
+
+// 👇 once stream is processed we are able to reflect current cell status
+func (c *Consumer) reflect() {
 	cursorAt := c.emu.State.Status.CursorAt
 	cell := c.emu.Buf.MustPeek(cursorAt)
 	c.emu.State.Patch(types.Patch{
@@ -44,11 +64,21 @@ func (c *Consumer) consume(chars []byte) {
 	})
 }
 
+// 🟦 Public functions
+
+// 👇 the program check of the last command, if it was rejected
+func (c *Consumer) Err() error {
+	if c.err == nil {
+		return nil
+	}
+	return c.err
+}
+
 // 🟦 Commands
 
-func (c *Consumer) commands(out *Outbound, cmd types.Command) {
+func (c *Consumer) commands(out *datastream.Outbound) {
 	// 👇 dispatch on command
-	switch cmd {
+	switch out.Cmd {
 
 	case types.EAU:
 		c.eau()
@@ -85,18 +115,18 @@ func (c *Consumer) eau() {
 	}
 }
 
-func (c *Consumer) ew(out *Outbound) {
+func (c *Consumer) ew(out *datastream.Outbound) {
 	if c.wcc(out) {
 		c.emu.Bus.PubReset()
-		c.orders(out)
+		c.orders(out.Orders)
 		c.emu.Bus.PubRender()
 	}
 }
 
-func (c *Consumer) ewa(out *Outbound) {
+func (c *Consumer) ewa(out *datastream.Outbound) {
 	if c.wcc(out) {
 		c.emu.Bus.PubReset()
-		c.orders(out)
+		c.orders(out.Orders)
 		c.emu.Bus.PubRender()
 	}
 }
@@ -105,6 +135,18 @@ func (c *Consumer) rb() {
 	c.emu.Bus.PubRB(types.INBOUND)
 }
 
+func (c *Consumer) reject() {
+	if !c.emu.Cfg.SuppressLogs {
+		println(fmt.Sprintf("🔥 %s", c.err))
+	}
+	// 🔥 State unlocks on any outbound, but not while we show an error
+	c.emu.State.Patch(types.Patch{
+		Error:   utils.BoolPtr(true),
+		Locked:  utils.BoolPtr(true),
+		Message: utils.StringPtr(c.err.Message()),
+	})
+}
+
 func (c *Consumer) rm() {
 	c.emu.Bus.PubRM(types.INBOUND)
 }
@@ -113,15 +155,15 @@ func (c *Consumer) rma() {
 	c.emu.Bus.PubRMA(types.INBOUND)
 }
 
-func (c *Consumer) w(out *Outbound) {
+func (c *Consumer) w(out *datastream.Outbound) {
 	c.wcc(out)
-	c.orders(out)
+	c.orders(out.Orders)
 	c.emu.Bus.PubRender()
 }
 
-func (c *Consumer) wcc(out *Outbound) bool {
-	if char, ok := out.Next(); ok {
-		wcc := types.NewWCC(char)
+func (c *Consumer) wcc(out *datastream.Outbound) bool {
+	if out.WCC != nil {
+		wcc := *out.WCC
 		// TODO 🔥 not yet handled
 		if wcc.Reset {
 			println("🔥 WCC Reset not implemented")
@@ -141,10 +183,9 @@ func (c *Consumer) wcc(out *Outbound) bool {
 
 // 🟦 WSF (which may contain multiple commands itself)
 
-func (c *Consumer) wsf(out *Outbound) {
-	// TODO 🔥 there are a million SF types
-	sflds := SFldsFromStream(out)
-	for _, sfld := range sflds {
+func (c *Consumer) wsf(out *datastream.Outbound) {
+	// TODO 🔥 there are a million SF types, all but these rejected by check
+	for _, sfld := range out.SFlds {
 
 		switch sfld.ID {
 
@@ -161,10 +202,9 @@ func (c *Consumer) wsf(out *Outbound) {
 	}
 }
 
-func (c *Consumer) rp(sfld SFld) {
-	pid := sfld.Info[0]
-	if pid == 0xfF {
-		cmd := sfld.Info[1]
+func (c *Consumer) rp(sfld datastream.SFld) {
+	if sfld.PID == 0xfF && len(sfld.Info) > 0 {
+		cmd := sfld.Info[0]
 
 		switch types.Command(cmd) {
 
@@ -172,7 +212,7 @@ func (c *Consumer) rp(sfld SFld) {
 			c.emu.Bus.PubQ()
 
 		case types.QL:
-			all := (sfld.Info[2] & 0b10000000) == 0b10000000
+			all := len(sfld.Info) > 1 && (sfld.Info[1]&0b10000000) == 0b10000000
 			var qcodes []types.QCode
 			if all {
 				qcodes = []types.QCode{
@@ -190,7 +230,7 @@ func (c *Consumer) rp(sfld SFld) {
 				}
 			} else {
 				qcodes = make([]types.QCode, 0)
-				for ix := 3; ix < len(sfld.Info); ix++ {
+				for ix := 2; ix < len(sfld.Info); ix++ {
 					qcodes = append(qcodes, types.QCode(sfld.Info[ix]))
 				}
 			}
@@ -209,63 +249,72 @@ func (c *Consumer) rp(sfld SFld) {
 	}
 }
 
-func (c *Consumer) srm(sfld SFld) {
-	pid := sfld.Info[0]
-	if pid == 0xfF {
-		mode := types.Mode(sfld.Info[1])
+func (c *Consumer) srm(sfld datastream.SFld) {
+	// 👇 the implicit partition is 0x00, but we have always accepted 0xfF
+	if (sfld.PID == 0x00 || sfld.PID == 0xfF) && len(sfld.Info) > 0 {
+		mode := types.Mode(sfld.Info[0])
 		c.emu.Buf.SetMode(mode)
 	}
 }
 
 // 🟦 Orders
 
-func (c *Consumer) orders(out *Outbound) {
-	var inFld bool
-	var fldAddr uint
-	fldAttrs := types.NewDefaultAttrs()
+// 👇 where the orders have left us, as each order may depend on it
+type fldState struct {
+	fldAddr  uint
+	fldAttrs *types.Attrs
+	inFld    bool
+}
 
-	// 👇 look at each byte to see if it is an order
-	for out.HasNext() {
-		char := out.MustNext()
-		order := types.Order(char)
-		// 👇 dispatch on order
-		switch order {
+func (c *Consumer) orders(orders []datastream.Order) {
+	fs := &fldState{fldAttrs: types.NewDefaultAttrs()}
+	// 👇 the debugger may want to step through the orders itself
+	if c.emu.Dbg.Enabled() {
+		c.emu.Dbg.load(orders, fs)
+		return
+	}
+	for _, order := range orders {
+		c.order(order, fs)
+	}
+}
 
-		case types.EUA:
-			c.eua(out)
+func (c *Consumer) order(order datastream.Order, fs *fldState) {
+	// 👇 dispatch on each order
+	switch order.Order {
 
-		case types.GE:
-			c.ge(out, fldAddr, fldAttrs, inFld)
+	case types.EUA:
+		c.eua(order)
 
-		case types.IC:
-			c.ic()
+	case types.GE:
+		c.ge(order, fs.fldAddr, fs.fldAttrs, fs.inFld)
 
-		case types.MF:
-			c.mf(out)
+	case types.IC:
+		c.ic()
 
-		case types.PT:
-			c.pt()
+	case types.MF:
+		c.mf(order)
 
-		case types.RA:
-			c.ra(out, fldAddr, fldAttrs, inFld)
+	case types.RA:
+		c.ra(order, fs.fldAddr, fs.fldAttrs, fs.inFld)
 
-		case types.SA:
-			fldAttrs = c.sa(out, fldAttrs)
+	case types.SA:
+		fs.fldAttrs = c.sa(order, fs.fldAttrs)
 
-		case types.SBA:
-			c.sba(out)
+	case types.SBA:
+		c.sba(order)
 
-		case types.SF:
-			inFld = true
-			fldAddr, fldAttrs = c.sf(out)
+	case types.SF:
+		fs.inFld = true
+		fs.fldAddr, fs.fldAttrs = c.sf(order)
 
-		case types.SFE:
-			inFld = true
-			fldAddr, fldAttrs = c.sfe(out)
+	case types.SFE:
+		fs.inFld = true
+		fs.fldAddr, fs.fldAttrs = c.sfe(order)
 
-		// 👇 if it isn't an order, it's data
-		default:
-			c.char(char, fldAddr, fldAttrs, inFld)
+	// 👇 if it isn't an order, it's data
+	case datastream.DATA:
+		for _, char := range order.Chars {
+			c.char(char, fs.fldAddr, fs.fldAttrs, fs.inFld)
 		}
 	}
 }
@@ -280,9 +329,8 @@ func (c *Consumer) char(char byte, fldAddr uint, fldAttrs *types.Attrs, inFld bo
 	c.emu.Buf.SetAndNext(cell)
 }
 
-func (c *Consumer) eua(out *Outbound) {
-	raw := out.MustNextSlice(2)
-	stop := conv.Bytes2Addr(raw)
+func (c *Consumer) eua(order datastream.Order) {
+	stop := order.Addr
 	// 👇 validate stop addr
 	_, ok := c.emu.Buf.Peek(stop)
 	if ok {
@@ -292,11 +340,10 @@ func (c *Consumer) eua(out *Outbound) {
 	}
 }
 
-func (c *Consumer) ge(out *Outbound, fldAddr uint, fldAttrs *types.Attrs, inFld bool) {
-	char := out.MustNext()
+func (c *Consumer) ge(order datastream.Order, fldAddr uint, fldAttrs *types.Attrs, inFld bool) {
 	// 👁️ qr/character-sets.go for where we announce support for this LCID
 	fldAttrs.LCID = 0xf1
-	c.char(char, fldAddr, fldAttrs, inFld)
+	c.char(order.Chars[0], fldAddr, fldAttrs, inFld)
 }
 
 func (c *Consumer) ic() {
@@ -305,34 +352,24 @@ func (c *Consumer) ic() {
 	})
 }
 
-func (c *Consumer) mf(out *Outbound) {
-	count := out.MustNext()
-	raw := out.MustNextSlice(int(count) * 2)
+func (c *Consumer) mf(order datastream.Order) {
 	cell, _ := c.emu.Buf.Get()
-	cell.Attrs = types.NewModifiedAttrs(cell.Attrs, raw)
+	cell.Attrs = types.NewModifiedAttrs(cell.Attrs, order.Pairs.Bytes())
 	c.emu.Buf.SetAndNext(cell)
 }
 
-// TODO 🔥 PT not handled
-func (c *Consumer) pt() {
-	c.emu.Bus.PubPanic("🔥 PT not implemented")
-}
-
-func (c *Consumer) ra(out *Outbound, fldAddr uint, fldAttrs *types.Attrs, inFld bool) {
-	raw := out.MustNextSlice(2)
-	stop := conv.Bytes2Addr(raw)
+func (c *Consumer) ra(order datastream.Order, fldAddr uint, fldAttrs *types.Attrs, inFld bool) {
+	stop := order.Addr
 	// 👇 validate stop addr
 	_, ok := c.emu.Buf.Peek(stop)
 	if ok {
-		char := out.MustNext()
-		if types.Order(char) == types.GE {
+		if order.GE {
 			// 👁️ qr/character-sets.go for where we announce support for this LCID
 			fldAttrs.LCID = 0xf1
-			char = out.MustNext()
 		}
 		cell := NewCell(c.emu)
 		cell.Attrs = fldAttrs
-		cell.Char = char
+		cell.Char = order.Chars[0]
 		if inFld {
 			cell.SetFldAddr(fldAddr)
 		}
@@ -342,29 +379,23 @@ func (c *Consumer) ra(out *Outbound, fldAddr uint, fldAttrs *types.Attrs, inFld
 	}
 }
 
-func (c *Consumer) sa(out *Outbound, fldAttrs *types.Attrs) *types.Attrs {
-	chars := out.MustNextSlice(2)
-	return types.NewModifiedAttrs(fldAttrs, chars)
+func (c *Consumer) sa(order datastream.Order, fldAttrs *types.Attrs) *types.Attrs {
+	return types.NewModifiedAttrs(fldAttrs, order.Pairs.Bytes())
 }
 
-func (c *Consumer) sba(out *Outbound) {
-	raw := out.MustNextSlice(2)
-	addr := conv.Bytes2Addr(raw)
-	c.emu.Buf.MustSeek(addr)
+func (c *Consumer) sba(order datastream.Order) {
+	c.emu.Buf.MustSeek(order.Addr)
 }
 
-func (c *Consumer) sf(out *Outbound) (uint, *types.Attrs) {
-	raw := out.MustNext()
-	fldAttrs := types.NewBasicAttrs(raw)
+func (c *Consumer) sf(order datastream.Order) (uint, *types.Attrs) {
+	fldAttrs := types.NewBasicAttrs(order.Attr)
 	fldAddr := c.emu.Buf.Addr()
 	c.sfImpl(fldAddr, fldAttrs)
 	return fldAddr, fldAttrs
 }
 
-func (c *Consumer) sfe(out *Outbound) (uint, *types.Attrs) {
-	count := out.MustNext()
-	raw := out.MustNextSlice(int(count) * 2)
-	fldAttrs := types.NewExtendedAttrs(raw)
+func (c *Consumer) sfe(order datastream.Order) (uint, *types.Attrs) {
+	fldAttrs := types.NewExtendedAttrs(order.Pairs.Bytes())
 	fldAddr := c.emu.Buf.Addr()
 	c.sfImpl(fldAddr, fldAttrs)
 	return fldAddr, fldAttrs
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"emulator/datastream"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerSRM(t *testing.T) {
+	tests := []struct {
+		name     string
+		pid      byte
+		expected types.Mode
+	}{
+		{"the implicit partition", 0x00, types.CHARACTER_MODE},
+		{"as we have always accepted", 0xff, types.CHARACTER_MODE},
+		{"any other partition", 0x01, types.FIELD_MODE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emu := MockEmulator(12, 40).Initialize()
+			emu.Bus.PubOutbound([]byte{byte(types.WSF), 0x00, 0x05, byte(types.SET_REPLY_MODE), tt.pid, byte(types.CHARACTER_MODE)})
+			assert.Equal(t, tt.expected, emu.Buf.Mode())
+		})
+	}
+
+	t.Run("as the builder writes it", func(t *testing.T) {
+		emu := MockEmulator(12, 40).Initialize()
+		emu.Bus.PubOutbound(datastream.NewBuilder(12, 40).
+			Command(types.WSF).
+			SetReplyMode(types.CHARACTER_MODE).
+			MustBytes())
+		assert.Equal(t, types.CHARACTER_MODE, emu.Buf.Mode())
+	})
+}
+
+// 👇 whatever the host sends, the emulator must neither crash nor give up
+func FuzzOutbound(f *testing.F) {
+	f.Add(MockStream(types.EW, types.WCC{Unlock: true}, keyboardImg, keyboardAttrs))
+	f.Add([]byte{byte(types.W), 0x00, byte(types.RA), 0x40, 0x40, byte(types.GE), 0xad})
+	f.Add([]byte{byte(types.WSF), 0x00, 0x05, byte(types.READ_PARTITION), 0xff, byte(types.QL), 0x80})
+	f.Add([]byte{byte(types.EWA), 0x00, byte(types.SFE), 0x02, byte(types.BASIC), 0x20, byte(types.MF), 0x01})
+	f.Add([]byte{byte(types.W), 0x00, byte(types.SA), byte(types.CHARSET), 0x42, 0xc1})
+	emu := MockEmulator(12, 40).Initialize()
+	// 🔥 testing forbids f.Fatal inside the fuzz function
+	var panicked string
+	emu.Bus.SubPanic(func(msg string) {
+		panicked = msg
+	})
+	f.Fuzz(func(t *testing.T, chars []byte) {
+		panicked = ""
+		emu.Bus.PubOutbound(chars)
+		assert.Empty(t, panicked)
+	})
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"emulator/datastream"
+	"emulator/types"
+)
+
+// 🟧 Step through the outbound data stream one order at a time
+
+// 👇 when enabled, the Consumer hands the orders of each write
+//    command here instead of processing them -- they are then
+//    processed on demand, rendering the screen after each one,
+//    and any stream that arrives meanwhile waits its turn
+
+type Debugger struct {
+	breakpoints map[uint]bool
+	enabled     bool
+	fs          *fldState
+	orders      []datastream.Order
+	queue       [][]byte
+
+	emu *Emulator // 👈 back pointer to all common components
+}
+
+// 👇 what a single order did to the buffer
+
+type Step struct {
+	Addr     uint             // 👈 buffer address after the order
+	Break    bool             // 👈 stopped because of a breakpoint
+	Done     bool             // 👈 no orders left to step through
+	FldAddr  uint             // 👈 buffer address of the current field
+	FldAttrs *types.Attrs     // 👈 attributes of any data that follows
+	InFld    bool             // 👈 true if there is a current field
+	Order    datastream.Order // 👈 the order just processed
+	Touched  []uint           // 👈 buffer addresses the order changed
+}
+
+// 🟦 Constructor
+
+func NewDebugger(emu *Emulator) *Debugger {
+	d := new(Debugger)
+	d.breakpoints = make(map[uint]bool)
+	d.emu = emu
+	return d
+}
+
+// 🟦 Public functions
+
+//    Break() stop when an order reaches an address
+//    Breakpoints() addresses at which we will stop
+//    Continue() step until a breakpoint or the last order
+//    Enable() start or stop stepping, finishing any command underway
+//    Enabled() true if outbound orders will be stepped
+//    Paused() true if there are orders still to step through
+//    Step() process the next order
+//    Unbreak() forget a breakpoint
+
+func (d *Debugger) Break(addr uint) bool {
+	if addr >= d.emu.Buf.Len() {
+		return false
+	}
+	d.breakpoints[addr] = true
+	return true
+}
+
+func (d *Debugger) Breakpoints() []uint {
+	addrs := make([]uint, 0, len(d.breakpoints))
+	for addr := uint(0); addr < d.emu.Buf.Len(); addr++ {
+		if d.breakpoints[addr] {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func (d *Debugger) Continue() (Step, bool) {
+	return d.run(true)
+}
+
+func (d *Debugger) Enable(enabled bool) {
+	d.enabled = enabled
+	// 👇 whatever is underway must finish, as no one will step it
+	if !enabled {
+		d.run(false)
+	}
+}
+
+func (d *Debugger) Enabled() bool {
+	return d.enabled
+}
+
+func (d *Debugger) Paused() bool {
+	return len(d.orders) > 0
+}
+
+func (d *Debugger) Step() (Step, bool) {
+	if !d.Paused() {
+		return Step{Done: true}, false
+	}
+	order := d.orders[0]
+	d.orders = d.orders[1:]
+	d.emu.Out.order(order, d.fs)
+	// 🔥 the buffer reuses its list, so we must copy it before rendering
+	touched, _ := d.emu.Buf.Touched()
+	attrs := *d.fs.fldAttrs
+	step := Step{
+		Addr:     d.emu.Buf.Addr(),
+		Done:     !d.Paused(),
+		FldAddr:  d.fs.fldAddr,
+		FldAttrs: &attrs,
+		InFld:    d.fs.inFld,
+		Order:    order,
+		Touched:  append([]uint{}, touched...),
+	}
+	// 👇 show what we've done so far
+	d.emu.Bus.PubRender()
+	if step.Done {
+		d.finish()
+	}
+	return step, true
+}
+
+func (d *Debugger) Unbreak(addr uint) {
+	delete(d.breakpoints, addr)
+}
+
+// 🟦 Helpers
+
+// 👇 as the consumer would have done, had it not been stepping
+func (d *Debugger) finish() {
+	d.fs = nil
+	d.emu.Out.reflect()
+	// 👇 now the next stream can go, though it too may be stepped
+	for !d.Paused() && len(d.queue) > 0 {
+		chars := d.queue[0]
+		d.queue = d.queue[1:]
+		d.emu.Out.consume(chars)
+	}
+	// 👇 the keyboard was locked while we stepped
+	d.emu.State.unlock(nil)
+}
+
+func (d *Debugger) hitBreakpoint(step Step) bool {
+	if d.breakpoints[step.Addr] {
+		return true
+	}
+	for _, addr := range step.Touched {
+		if d.breakpoints[addr] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Debugger) hold(chars []byte) bool {
+	if d.Paused() {
+		d.queue = append(d.queue, chars)
+		return true
+	}
+	return false
+}
+
+func (d *Debugger) load(orders []datastream.Order, fs *fldState) {
+	// 🔥 a write with no orders has nothing to step through
+	if len(orders) > 0 {
+		d.fs = fs
+		d.orders = orders
+	}
+}
+
+func (d *Debugger) run(honorBreakpoints bool) (Step, bool) {
+	for {
+		step, ok := d.Step()
+		if !ok {
+			return step, false
+		}
+		if honorBreakpoints && d.hitBreakpoint(step) {
+			step.Break = true
+			return step, true
+		}
+		if step.Done {
+			return step, true
+		}
+	}
+}
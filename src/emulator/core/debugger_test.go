@@ -0,0 +1,105 @@
+package core
+
+import (
+	"emulator/datastream"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func debuggerStream() []byte {
+	return datastream.NewBuilder(12, 40).
+		Command(types.EW).WCC(types.WCC{Unlock: true}).
+		SBA(2, 1).SF(&types.Attrs{Protected: true}).Text("Name").
+		SF(&types.Attrs{Numeric: true}).IC().
+		MustBytes()
+}
+
+func TestDebuggerStep(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Dbg.Enable(true)
+	emu.Bus.PubOutbound(debuggerStream())
+	assert.True(t, emu.Dbg.Paused())
+	assert.True(t, emu.State.Status.Locked, "the operator can't type into a half-written buffer")
+	emu.Bus.PubKeystroke(types.Keystroke{CTRL: true, Code: "KeyR", Key: "r"})
+	emu.Bus.PubFocus(true)
+	emu.Bus.PubPaste("oops")
+	assert.True(t, emu.State.Status.Locked, "not even after RESET")
+	// 👇 nothing written yet
+	assert.Equal(t, "", emu.Cells.Copy(emu.Cfg.RC2Addr(2, 2), emu.Cfg.RC2Addr(2, 5), false))
+
+	step, ok := emu.Dbg.Step()
+	assert.True(t, ok)
+	assert.Equal(t, types.SBA, step.Order.Order)
+	assert.Equal(t, emu.Cfg.RC2Addr(2, 1), step.Addr)
+	assert.False(t, step.InFld)
+	assert.Empty(t, step.Touched)
+
+	step, _ = emu.Dbg.Step()
+	assert.Equal(t, types.SF, step.Order.Order)
+	assert.True(t, step.InFld)
+	assert.Equal(t, emu.Cfg.RC2Addr(2, 1), step.FldAddr)
+	assert.True(t, step.FldAttrs.Protected)
+	assert.Equal(t, []uint{emu.Cfg.RC2Addr(2, 1)}, step.Touched)
+
+	step, _ = emu.Dbg.Step()
+	assert.Equal(t, datastream.DATA, step.Order.Order)
+	assert.Len(t, step.Touched, 4)
+	assert.Equal(t, "Name", emu.Cells.Copy(emu.Cfg.RC2Addr(2, 2), emu.Cfg.RC2Addr(2, 5), false))
+	assert.False(t, step.Done)
+	assert.True(t, emu.State.Status.Locked)
+
+	// 👇 the last order finishes the command
+	emu.Dbg.Step()
+	step, _ = emu.Dbg.Step()
+	assert.Equal(t, types.IC, step.Order.Order)
+	assert.True(t, step.Done)
+	assert.False(t, emu.Dbg.Paused())
+	assert.False(t, emu.State.Status.Locked)
+	assert.Equal(t, emu.Cfg.RC2Addr(2, 7), emu.State.Status.CursorAt)
+	assert.True(t, emu.State.Status.Numeric)
+
+	_, ok = emu.Dbg.Step()
+	assert.False(t, ok, "nothing left to step")
+}
+
+func TestDebuggerContinue(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Dbg.Enable(true)
+	assert.True(t, emu.Dbg.Break(emu.Cfg.RC2Addr(2, 3)))
+	assert.False(t, emu.Dbg.Break(emu.Buf.Len()))
+	assert.Equal(t, []uint{emu.Cfg.RC2Addr(2, 3)}, emu.Dbg.Breakpoints())
+	emu.Bus.PubOutbound(debuggerStream())
+
+	// 👇 the data runs over the breakpoint
+	step, ok := emu.Dbg.Continue()
+	assert.True(t, ok)
+	assert.True(t, step.Break)
+	assert.Equal(t, datastream.DATA, step.Order.Order)
+
+	emu.Dbg.Unbreak(emu.Cfg.RC2Addr(2, 3))
+	step, _ = emu.Dbg.Continue()
+	assert.False(t, step.Break)
+	assert.True(t, step.Done)
+}
+
+func TestDebuggerQueue(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Dbg.Enable(true)
+	emu.Bus.PubOutbound(debuggerStream())
+	// 👇 the second stream waits for the first
+	emu.Bus.PubOutbound(datastream.NewBuilder(12, 40).
+		Command(types.W).WCC(types.WCC{}).
+		SBA(3, 1).Text("Next").
+		MustBytes())
+	assert.Equal(t, "", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 1), emu.Cfg.RC2Addr(3, 4), false))
+	emu.Dbg.Continue()
+	assert.True(t, emu.Dbg.Paused(), "second stream is stepped too")
+	assert.True(t, emu.State.Status.Locked)
+	// 👇 disabling runs everything to completion
+	emu.Dbg.Enable(false)
+	assert.False(t, emu.Dbg.Paused())
+	assert.False(t, emu.State.Status.Locked)
+	assert.Equal(t, "Next", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 1), emu.Cfg.RC2Addr(3, 4), false))
+}
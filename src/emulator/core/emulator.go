@@ -13,11 +13,14 @@ type Emulator struct {
 	Bus   *Bus
 	Cells *Cells
 	Cfg   *types.Config
+	Dbg   *Debugger
 	Flds  *Flds
 	GC    *Cache
 	Kbd   *Keyboard
 	In    *Producer
+	Insp  *Inspector
 	Log   *Logger
+	Mouse *Mouse
 	Out   *Consumer
 	Scr   *Screen
 	State *State
@@ -32,13 +35,16 @@ func NewEmulator(bus *Bus, cfg *types.Config) *Emulator {
 	// 🔥 preserve order of components for pubsub!
 	e.Buf = NewBuffer(e)
 	e.Cells = NewCells(e)
+	e.Dbg = NewDebugger(e)
 	e.Flds = NewFlds(e)
 	e.GC = NewCache(e)
 	e.In = NewProducer(e)
+	e.Insp = NewInspector(e)
 	e.Kbd = NewKeyboard(e)
 	if !cfg.SuppressLogs {
 		e.Log = NewLogger(e)
 	}
+	e.Mouse = NewMouse(e)
 	e.Out = NewConsumer(e)
 	e.Scr = NewScreen(e)
 	e.State = NewState(e)
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"emulator/fonts"
+	"emulator/types"
+	"image"
+	"math"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// 🟧 Load, measure and fit the fonts used to draw the screen
+
+// 👇 a face that falls back, glyph by glyph, to other fonts
+//    for any rune it lacks, like APL or box drawing characters
+
+type Fallback struct {
+	font.Face // 👈 the primary face supplies the metrics
+
+	faces []font.Face
+	fonts []*truetype.Font
+}
+
+// 🟦 Constructor
+
+func NewFallback(fontSize, dpi float64, ttfs ...[]byte) (*Fallback, error) {
+	f := new(Fallback)
+	for _, ttf := range ttfs {
+		parsed, err := parse(ttf)
+		if err != nil {
+			return nil, err
+		}
+		f.fonts = append(f.fonts, parsed)
+		f.faces = append(f.faces, truetype.NewFace(parsed, &truetype.Options{Size: fontSize, DPI: dpi /* , Hinting: font.HintingFull */}))
+	}
+	f.Face = f.faces[0]
+	return f, nil
+}
+
+// 🟦 font.Face implementation
+
+func (f *Fallback) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return f.faceFor(r).Glyph(dot, r)
+}
+
+func (f *Fallback) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return f.faceFor(r).GlyphAdvance(r)
+}
+
+func (f *Fallback) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return f.faceFor(r).GlyphBounds(r)
+}
+
+// 🟦 Helpers
+
+func (f *Fallback) faceFor(r rune) font.Face {
+	for ix, ttf := range f.fonts {
+		if ttf.Index(r) != 0 {
+			return f.faces[ix]
+		}
+	}
+	return f.Face
+}
+
+// 🔥 parsing is expensive (JuliaMono is 3MB) so we remember each
+//    embedded font -- sessions may render concurrently, hence the lock,
+//    and caller-supplied fonts are never remembered, lest we grow forever
+
+var (
+	parsed   = make(map[*byte]*truetype.Font)
+	parsedMu sync.Mutex
+)
+
+func parse(ttf []byte) (*truetype.Font, error) {
+	if !fonts.IsEmbedded(ttf) {
+		return truetype.Parse(ttf)
+	}
+	parsedMu.Lock()
+	defer parsedMu.Unlock()
+	if f, ok := parsed[&ttf[0]]; ok {
+		return f, nil
+	}
+	f, err := truetype.Parse(ttf)
+	if err == nil {
+		parsed[&ttf[0]] = f
+	}
+	return f, err
+}
+
+// 🟦 Public functions
+
+// 👇 the canvas holds every cell, plus a row for the OIA if required
+func CanvasRect(cfg *types.Config) image.Rectangle {
+	rows := cfg.Rows
+	if cfg.OIA {
+		rows++
+	}
+	box := NewBox(rows, cfg.Cols, cfg)
+	return image.Rect(0, 0, int(box.X+box.W), int(box.Y+box.H))
+}
+
+// 👇 for an emulator without a browser: no fonts and no image, so the
+//    cell size is only what the query reply tells the host -- unless
+//    we are to render, when the screen is drawn as in the browser
+
+func HeadlessConfig(rows, cols uint, render bool) (*types.Config, error) {
+	p, _ := types.ProfileOf("3279")
+	cfg := &types.Config{
+		Cols:         cols,
+		FontHeight:   16,
+		FontWidth:    9,
+		PaddedHeight: 1,
+		PaddedWidth:  1,
+		Rows:         rows,
+		SuppressLogs: true,
+	}
+	cfg.ApplyProfile(p)
+	if render {
+		normalFace, boldFace, fontWidth, fontHeight, err := NewFaces(fonts.DefaultFamily, 12, 96)
+		if err != nil {
+			return nil, err
+		}
+		cfg.BoldFace = &boldFace
+		cfg.DPI = 96
+		cfg.FontHeight = fontHeight
+		cfg.FontSize = 12
+		cfg.FontWidth = fontWidth
+		cfg.NormalFace = &normalFace
+		cfg.PaddedHeight = 1.5
+		cfg.PaddedWidth = 1.1
+		cfg.RGBA = image.NewRGBA(CanvasRect(cfg))
+	}
+	return cfg, nil
+}
+
+// 👇 the largest font (to the nearest half point) whose canvas fits
+func FitFontSize(cfg *types.Config, width, height float64) float64 {
+	temp := *cfg
+	lo, hi := 4, 144
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		temp.FontSize = float64(mid) / 2
+		normalFace, _, fontWidth, fontHeight, err := NewFaces(temp.Family, temp.FontSize, temp.DPI)
+		if err != nil {
+			break
+		}
+		temp.FontHeight, temp.FontWidth, temp.NormalFace = fontHeight, fontWidth, &normalFace
+		rect := CanvasRect(&temp)
+		if float64(rect.Dx()) <= width && float64(rect.Dy()) <= height {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return float64(lo) / 2
+}
+
+// 👇 cells are as wide as a bold "M" and as high as ascent + descent
+func NewFaces(family fonts.Family, fontSize, dpi float64) (normalFace, boldFace font.Face, fontWidth, fontHeight float64, err error) {
+	if family.Normal == nil {
+		family = fonts.DefaultFamily
+	}
+	// 🔥 every family falls back to the default for missing glyphs
+	normal, err := NewFallback(fontSize, dpi, family.Normal, fonts.NormalFontEmbed)
+	if err != nil {
+		return
+	}
+	bold, err := NewFallback(fontSize, dpi, family.Bold, fonts.BoldFontEmbed)
+	if err != nil {
+		return
+	}
+	advance, _ := bold.GlyphAdvance('M')
+	metrics := normal.Metrics()
+	fontWidth = math.Ceil(fix2f64(advance))
+	fontHeight = math.Ceil(fix2f64(metrics.Ascent + metrics.Descent))
+	return normal, bold, fontWidth, fontHeight, nil
+}
+
+func fix2f64(f fixed.Int26_6) float64 {
+	return float64(f) / 64
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"emulator/fonts"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitFontSize(t *testing.T) {
+	emu := MockEmulator(24, 80)
+	fontSize := FitFontSize(emu.Cfg, 1024, 768)
+	assert.Greater(t, fontSize, 2.0)
+	// 👇 the chosen size fits, but the next one up does not
+	cfg := *emu.Cfg
+	for _, size := range []float64{fontSize, fontSize + 0.5} {
+		cfg.FontSize = size
+		_, _, cfg.FontWidth, cfg.FontHeight, _ = NewFaces(cfg.Family, cfg.FontSize, cfg.DPI)
+		rect := CanvasRect(&cfg)
+		fits := rect.Dx() <= 1024 && rect.Dy() <= 768
+		assert.Equal(t, size == fontSize, fits, size)
+	}
+}
+
+func TestHeadlessConfig(t *testing.T) {
+	cfg, err := HeadlessConfig(24, 80, false)
+	assert.NoError(t, err)
+	assert.Nil(t, cfg.RGBA, "nothing to draw on")
+	assert.Equal(t, 16.0, cfg.FontHeight, "as the query reply says")
+	assert.True(t, cfg.SuppressLogs)
+	assert.NotEmpty(t, cfg.CLUT, "the 3279 profile")
+	cfg, err = HeadlessConfig(24, 80, true)
+	assert.NoError(t, err)
+	assert.Equal(t, CanvasRect(cfg), cfg.RGBA.Bounds())
+	assert.NotNil(t, cfg.NormalFace)
+}
+
+func TestNewFaces(t *testing.T) {
+	for _, name := range fonts.FamilyNames() {
+		family, _ := fonts.FamilyOf(name)
+		normal, bold, w, h, err := NewFaces(family, 12, 96)
+		assert.NoError(t, err, name)
+		assert.NotNil(t, normal, name)
+		assert.NotNil(t, bold, name)
+		// 👇 cell height is the font's ascent + descent
+		metrics := normal.Metrics()
+		assert.Equal(t, (metrics.Ascent + metrics.Descent).Ceil(), int(h), name)
+		assert.Positive(t, w, name)
+	}
+	_, _, _, _, err := NewFaces(fonts.UserFamily("junk", []byte("junk")), 12, 96)
+	assert.Error(t, err, "user font can't be parsed")
+}
+
+func TestFallback(t *testing.T) {
+	family, _ := fonts.FamilyOf("IBMPlexMono")
+	normal, _, _, _, _ := NewFaces(family, 12, 96)
+	fallback := normal.(*Fallback)
+	// 👇 IBM Plex lacks APL, so JuliaMono steps in
+	assert.Equal(t, fallback.faces[0], fallback.faceFor('A'))
+	assert.Equal(t, fallback.faces[1], fallback.faceFor('⍴'))
+	_, ok := fallback.GlyphAdvance('⍴')
+	assert.True(t, ok)
+}
+
+func TestParse(t *testing.T) {
+	// 👇 sessions may load fonts concurrently
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _, _, err := NewFaces(fonts.DefaultFamily, 12, 96)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	// 👇 a caller-supplied font is not remembered
+	ttf := append([]byte{}, fonts.NormalFontEmbed...)
+	_, err := parse(ttf)
+	assert.NoError(t, err)
+	parsedMu.Lock()
+	defer parsedMu.Unlock()
+	_, ok := parsed[&ttf[0]]
+	assert.False(t, ok)
+	_, ok = parsed[&fonts.NormalFontEmbed[0]]
+	assert.True(t, ok)
+}
@@ -30,7 +30,7 @@ func (f Fld) String() string {
 	for ix := 1; ix < len(f.Cells); ix++ {
 		cell := f.Cells[ix]
 		if cell.Char >= 0x40 {
-			b.WriteRune(conv.E2Rune(cell.Attrs.LCID, cell.Char))
+			b.WriteRune(conv.E2RuneCP(f.emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char))
 		}
 	}
 	return strings.TrimSpace(b.String())
@@ -106,10 +106,12 @@ func (f *Flds) EAU() (uint, bool) {
 				addr, firstFld = sf.GetFldAddr()
 			}
 			// 🔥 reset char and any character attributes
+			start, _ := sf.GetFldAddr()
 			for ix := 1; ix < len(fld.Cells); ix++ {
 				cell := fld.Cells[ix]
 				cell.Char = 0x00
 				cell.Attrs = sf.Attrs
+				f.emu.Buf.Touch(f.emu.Buf.WrapAddr(int(start) + ix))
 			}
 		}
 	}
@@ -165,3 +167,19 @@ func (f *Flds) RM() []byte {
 	}
 	return chars
 }
+
+// 👁️ Selector pen attention, as per Read Modified pp 3-13 to 3-15
+func (f *Flds) SelPen() []byte {
+	chars := make([]byte, 0)
+	for _, fld := range f.Flds {
+		sf := fld.Cells[0]
+		// 👇 just the address of each changed field
+		if sf.Attrs.MDT {
+			chars = append(chars, byte(types.SBA))
+			addr, _ := sf.GetFldAddr()
+			next := f.emu.Buf.WrapAddr(int(addr) + 1)
+			chars = append(chars, conv.Addr2Bytes(next)...)
+		}
+	}
+	return chars
+}
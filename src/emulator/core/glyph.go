@@ -5,13 +5,18 @@ import "emulator/types"
 // 🟧 A glyph, as stored in the glyph cache
 
 type Glyph struct {
-	Char       byte
-	Color      string
-	Highlight  bool
-	LCID       types.LCID
-	Outline    Outline
-	Reverse    bool
-	Underscore bool
+	Char            byte
+	Color           string
+	CursorBar       bool
+	CursorBlock     bool
+	CursorUnderline bool
+	Highlight       bool
+	LCID            types.LCID
+	Outline         Outline
+	Overlay         Overlay
+	Reverse         bool
+	Ruler           Ruler
+	Underscore      bool
 }
 
 type Outline struct {
@@ -21,6 +26,35 @@ type Outline struct {
 	Left   bool
 }
 
+// 👇 the debug overlay shows what is normally invisible: where fields
+//    start, what kind of field a cell is in, and so on
+
+type Overlay struct {
+	CharAttr bool // 👈 the cell has its own character attributes
+	FldStart bool // 👈 the cell is an SF/SFE
+	Hidden   bool // 👈 non-display data, revealed
+	MDT      bool // 👈 the field starting here has been modified
+	Tint     Tint
+}
+
+type Tint int
+
+const (
+	TINT_NONE Tint = iota
+	TINT_NUMERIC
+	TINT_PROTECTED
+	TINT_UNPROTECTED
+)
+
+// 👇 the crosshair passes through the cursor's row and column, and
+//    Readout is a character of the cursor's position, if any
+
+type Ruler struct {
+	Col     bool
+	Readout rune
+	Row     bool
+}
+
 // 🟦 Constructor
 
 func NewGlyph() *Glyph {
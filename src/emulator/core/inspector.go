@@ -0,0 +1,135 @@
+package core
+
+import (
+	"emulator/conv"
+	"emulator/types"
+)
+
+// 🟧 Inspect cells and fields as data, say for a devtools panel
+
+type Inspector struct {
+	emu *Emulator // 👈 back pointer to all common components
+}
+
+// 👇 everything we know about the cell at an address
+
+type CellInfo struct {
+	Addr     uint
+	Attrs    types.Attrs // 👈 as drawn, which may be the field's
+	Char     byte        // 👈 EBCDIC, as held in the buffer
+	Col      uint
+	Fld      *FldInfo // 👈 nil if the cell isn't in a field
+	FldHome  bool     // 👈 first data cell of its field
+	FldStart bool     // 👈 the SF/SFE itself
+	Row      uint
+	Rune     rune // 👈 0 for nulls and field starts
+}
+
+// 👇 everything we know about a field
+
+type FldInfo struct {
+	Addr       uint        // 👈 of the SF/SFE
+	Attrs      types.Attrs // 👈 field attributes
+	Col        uint
+	End        uint // 👈 address of the last cell, which may be the SF itself
+	Home       uint // 👈 address of the first data cell
+	HasHome    bool // 👈 false if the field has no data cells
+	Len        uint // 👈 number of data cells
+	MDT        bool
+	Row        uint
+	Validation Validation
+	Value      string // 👈 trimmed, as Fld.String()
+}
+
+// 👇 what the keyboard will check when the operator types here
+
+type Validation struct {
+	Autoskip bool // 👈 the cursor skips over the field
+	Input    bool // 👈 the operator may type into the field
+	Numeric  bool // 👈 only numeric input is accepted
+}
+
+// 🟦 Constructor
+
+func NewInspector(emu *Emulator) *Inspector {
+	i := new(Inspector)
+	i.emu = emu
+	return i
+}
+
+// 🟦 Public functions
+
+//    Cell() details of the cell at an address
+//    Fld() details of the field that starts at an address
+//    Flds() details of every field, in buffer order
+
+func (i *Inspector) Cell(addr uint) (CellInfo, bool) {
+	cell, ok := i.emu.Buf.Peek(addr)
+	if !ok || cell == nil {
+		return CellInfo{}, false
+	}
+	row, col := i.emu.Cfg.Addr2RC(addr)
+	info := CellInfo{
+		Addr:     addr,
+		Attrs:    *cell.Attrs,
+		Char:     cell.Char,
+		Col:      col,
+		FldHome:  cell.IsFldHome(),
+		FldStart: cell.IsFldStart(),
+		Row:      row,
+	}
+	// 🔥 hidden data is shown, as that's what inspectors are for
+	if !info.FldStart && cell.Char >= 0x40 {
+		info.Rune = conv.E2RuneCP(i.emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char)
+	}
+	if fld, ok := cell.FindFld(); ok {
+		fldInfo := i.fldInfo(fld)
+		info.Fld = &fldInfo
+	}
+	return info, true
+}
+
+func (i *Inspector) Fld(addr uint) (FldInfo, bool) {
+	fld, ok := i.emu.Flds.FindFld(addr)
+	if !ok {
+		return FldInfo{}, false
+	}
+	return i.fldInfo(fld), true
+}
+
+func (i *Inspector) Flds() []FldInfo {
+	infos := make([]FldInfo, len(i.emu.Flds.Flds))
+	for ix, fld := range i.emu.Flds.Flds {
+		infos[ix] = i.fldInfo(fld)
+	}
+	return infos
+}
+
+// 🟦 Helpers
+
+func (i *Inspector) fldInfo(fld *Fld) FldInfo {
+	sf := fld.Cells[0]
+	addr, _ := sf.GetFldAddr()
+	row, col := i.emu.Cfg.Addr2RC(addr)
+	a := sf.Attrs
+	info := FldInfo{
+		Addr:  addr,
+		Attrs: *a,
+		Col:   col,
+		End:   i.emu.Buf.WrapAddr(int(addr) + len(fld.Cells) - 1),
+		Len:   uint(len(fld.Cells) - 1),
+		MDT:   a.MDT,
+		Row:   row,
+		Validation: Validation{
+			Autoskip: a.Autoskip,
+			Input:    !a.Protected,
+			Numeric:  a.Numeric && !a.Protected,
+		},
+		Value: fld.String(),
+	}
+	if _, ok := sf.GetFldHome(); ok {
+		info.Home = i.emu.Buf.WrapAddr(int(addr) + 1)
+		info.HasHome = true
+	}
+	return info
+}
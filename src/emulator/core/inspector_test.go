@@ -0,0 +1,69 @@
+package core
+
+import (
+	"emulator/types"
+	"emulator/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectorCell(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, MockExampleImg, MockExampleAttrs))
+
+	t.Run("a data cell", func(t *testing.T) {
+		info, ok := emu.Insp.Cell(emu.Cfg.RC2Addr(1, 11))
+		assert.True(t, ok)
+		assert.Equal(t, uint(1), info.Row)
+		assert.Equal(t, uint(11), info.Col)
+		assert.Equal(t, byte(0xe3), info.Char)
+		assert.Equal(t, 'T', info.Rune)
+		assert.True(t, info.FldHome)
+		assert.False(t, info.FldStart)
+		assert.True(t, info.Attrs.Protected)
+		assert.Equal(t, emu.Cfg.RC2Addr(1, 10), info.Fld.Addr)
+		assert.Equal(t, "Test screen", info.Fld.Value)
+	})
+
+	t.Run("a field start", func(t *testing.T) {
+		info, _ := emu.Insp.Cell(emu.Cfg.RC2Addr(3, 21))
+		assert.True(t, info.FldStart)
+		assert.Equal(t, rune(0), info.Rune)
+		assert.False(t, info.Fld.Attrs.Protected)
+		assert.True(t, info.Fld.Validation.Input)
+		assert.True(t, info.Fld.HasHome)
+		assert.Equal(t, emu.Cfg.RC2Addr(3, 22), info.Fld.Home)
+		assert.Equal(t, emu.Cfg.RC2Addr(3, 39), info.Fld.End)
+		assert.Equal(t, uint(18), info.Fld.Len)
+	})
+
+	t.Run("off the screen", func(t *testing.T) {
+		_, ok := emu.Insp.Cell(emu.Buf.Len())
+		assert.False(t, ok)
+	})
+}
+
+func TestInspectorFlds(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{}, keyboardImg, keyboardAttrs))
+	flds := emu.Insp.Flds()
+	assert.Len(t, flds, 11)
+	assert.Equal(t, "First name ?", flds[1].Value)
+	assert.Equal(t, emu.Cfg.RC2Addr(3, 1), flds[1].Addr)
+	assert.False(t, flds[1].Validation.Input)
+	assert.True(t, flds[2].Validation.Input)
+	assert.False(t, flds[2].Validation.Numeric)
+	assert.True(t, flds[8].Validation.Numeric)
+
+	// 👇 typing sets the MDT
+	emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15))})
+	emu.Bus.PubPaste("John")
+	fld, ok := emu.Insp.Fld(emu.Cfg.RC2Addr(3, 14))
+	assert.True(t, ok)
+	assert.True(t, fld.MDT)
+	assert.Equal(t, "John", fld.Value)
+
+	_, ok = emu.Insp.Fld(emu.Cfg.RC2Addr(2, 1))
+	assert.False(t, ok, "no field starts there")
+}
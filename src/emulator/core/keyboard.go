@@ -7,6 +7,7 @@ import (
 
 	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 // 🟧 Respond to keyboard input
@@ -27,16 +28,33 @@ func NewKeyboard(emu *Emulator) *Keyboard {
 	k.emu = emu
 	// 👇 subscriptions
 	k.emu.Bus.SubKeystroke(k.keystroke)
+	k.emu.Bus.SubCursor(k.cursor)
 	k.emu.Bus.SubFocus(k.focus)
+	k.emu.Bus.SubPaste(k.paste)
 	return k
 }
 
+// 🟦 Move the cursor on request, as a click or a script would
+
+func (k *Keyboard) cursor(addr uint) {
+	deltas := utils.NewStack[uint](2)
+	k.MoveCursor(k.emu.State.Status.CursorAt, addr, deltas)
+	if !deltas.Empty() {
+		k.emu.Bus.PubRenderDeltas(deltas)
+	}
+}
+
 // 🟦 Gain/lose focus
 
 func (k *Keyboard) focus(focussed bool) {
+	// 🔥 any other error (eg: X PROG) outlasts focus, until RESET
+	stat := k.emu.State.Status
+	if stat.Error && stat.Message != "LOCK" {
+		return
+	}
 	k.emu.State.Patch(types.Patch{
 		Error:   utils.BoolPtr(!focussed),
-		Locked:  utils.BoolPtr(!focussed),
+		Locked:  utils.BoolPtr(!focussed || k.emu.Dbg.Paused()),
 		Message: utils.StringPtr(utils.Ternary(focussed, "", "LOCK")),
 	})
 }
@@ -44,6 +62,11 @@ func (k *Keyboard) focus(focussed bool) {
 // 🟦 Dispatch action per key code
 
 func (k *Keyboard) keystroke(key types.Keystroke) {
+	// 🔥 no typing into a buffer the debugger has only half written
+	if k.emu.Dbg.Paused() {
+		k.emu.State.Patch(types.Patch{Alarm: utils.BoolPtr(true)})
+		return
+	}
 	// 👇 see if we are in insert mode
 	insertMode := k.emu.State.Status.Insert
 	// 👇 prepare to move the cursor -- many keystrokes do this
@@ -124,11 +147,15 @@ func (k *Keyboard) keystroke(key types.Keystroke) {
 			Insert: utils.BoolPtr(!insertMode),
 		})
 
+	case key.CTRL && key.Code == "KeyR":
+		k.reset()
+
 	case key.Code == "Tab":
 		cursorTo, ok = k.tab(utils.Ternary(key.SHIFT, -1, +1), cursorAt)
 
-	case len(key.Key) == 1:
-		cursorTo, ok = k.keyin(key.Key[0], cursorAt, deltas, insertMode)
+	case utf8.RuneCountInString(key.Key) == 1:
+		r, _ := utf8.DecodeRuneInString(key.Key)
+		cursorTo, ok = k.keyin(r, cursorAt, deltas, insertMode, false)
 
 	}
 
@@ -143,6 +170,77 @@ func (k *Keyboard) keystroke(key types.Keystroke) {
 	}
 
 	// 👇 only if the cursor has moved!
+	k.MoveCursor(cursorAt, cursorTo, deltas)
+	// 👇 render any changes
+	if !deltas.Empty() {
+		k.emu.Bus.PubRenderDeltas(deltas)
+	}
+}
+
+// 🟦 Paste text as if typed
+
+func (k *Keyboard) paste(text string) {
+	if k.emu.Dbg.Paused() {
+		k.emu.State.Patch(types.Patch{Alarm: utils.BoolPtr(true)})
+		return
+	}
+	insertMode := k.emu.State.Status.Insert
+	cursorAt := k.emu.State.Status.CursorAt
+	cursorTo := cursorAt
+	// 🔥 all pasted characters are rendered in a single batch
+	deltas := utils.NewStack[uint](len(text))
+	ok := true
+
+	for _, r := range strings.ReplaceAll(text, "\r\n", "\n") {
+		k.emu.Buf.MustSeek(cursorTo)
+
+		switch {
+
+		case r == '\n' || r == '\r':
+			cursorTo, ok = k.newline(cursorTo)
+
+		case r == '\t':
+			cursorTo, ok = k.tab(+1, cursorTo)
+
+		default:
+			cursorTo, ok = k.pasteChar(r, cursorTo, deltas, insertMode)
+
+		}
+
+		// 👇 stop at the first character we can't place
+		if !ok {
+			k.emu.State.Patch(types.Patch{Alarm: utils.BoolPtr(true)})
+			break
+		}
+	}
+
+	// 👇 cursor ends up after the last character pasted
+	k.MoveCursor(cursorAt, cursorTo, deltas)
+	if !deltas.Empty() {
+		k.emu.Bus.PubRenderDeltas(deltas)
+	}
+}
+
+// 👇 as if typed, but from a protected area we skip ahead, and at a
+//    field end we wrap into the next unprotected field, autoskip or not
+
+func (k *Keyboard) pasteChar(char rune, cursorAt uint, deltas *utils.Stack[uint], insertMode bool) (uint, bool) {
+	cell, _ := k.emu.Buf.Get()
+	if cell.IsFldStart() || cell.Attrs.Protected {
+		addr, ok := k.tab(+1, cursorAt)
+		if !ok {
+			return cursorAt, false
+		}
+		cursorAt = addr
+	}
+	deltas.Push(cursorAt)
+	return k.keyin(char, cursorAt, deltas, insertMode, true)
+}
+
+// 🟦 Public functions
+
+// 👇 also used by the mouse, so cursor status is always consistent
+func (k *Keyboard) MoveCursor(cursorAt, cursorTo uint, deltas *utils.Stack[uint]) {
 	if cursorTo != cursorAt {
 		deltas.Push(cursorAt)
 		deltas.Push(cursorTo)
@@ -155,10 +253,6 @@ func (k *Keyboard) keystroke(key types.Keystroke) {
 			Protected: utils.BoolPtr(cell.Attrs.Protected || cell.IsFldStart()),
 		})
 	}
-	// 👇 render any changes
-	if !deltas.Empty() {
-		k.emu.Bus.PubRenderDeltas(deltas)
-	}
 }
 
 // 🟦 BACKSPACE
@@ -251,18 +345,23 @@ func (k *Keyboard) home(dfltAddr uint) (uint, bool) {
 
 // 🟦 KEYSTROKE
 
-func (k *Keyboard) keyin(char byte, dfltAddr uint, deltas *utils.Stack[uint], insertMode bool) (uint, bool) {
+// 👇 wrap to the next unprotected field at a field end, even if the
+//    field that ends it is not autoskip
+
+func (k *Keyboard) keyin(char rune, dfltAddr uint, deltas *utils.Stack[uint], insertMode bool, wrap bool) (uint, bool) {
 	cell, _ := k.emu.Buf.Get()
-	if k.keyinvalid(cell, char) || !k.keyinMDT(cell) {
+	// 👇 only what the code page can show, as the host will see it
+	e, ok := conv.Rune2ECP(k.emu.Cfg.CodePage, char)
+	if !ok || k.keyinvalid(cell, char) || !k.keyinMDT(cell) {
 		return dfltAddr, false
 	}
 	if insertMode {
-		return k.keyinsert(cell, char, dfltAddr, deltas)
+		return k.keyinsert(cell, e, dfltAddr, deltas)
 	}
-	return k.keyinover(cell, char, dfltAddr)
+	return k.keyinover(cell, e, dfltAddr, wrap)
 }
 
-func (k *Keyboard) keyinvalid(cell *Cell, char byte) bool {
+func (k *Keyboard) keyinvalid(cell *Cell, char rune) bool {
 	numlock := cell.Attrs.Numeric && !strings.Contains("-0123456789.", string(char))
 	prot := cell.IsFldStart() || cell.Attrs.Protected
 	if numlock || prot {
@@ -280,7 +379,7 @@ func (k *Keyboard) keyinMDT(cell *Cell) bool {
 	return true
 }
 
-func (k *Keyboard) keyinsert(cell *Cell, char byte, dfltAddr uint, deltas *utils.Stack[uint]) (uint, bool) {
+func (k *Keyboard) keyinsert(cell *Cell, e byte, dfltAddr uint, deltas *utils.Stack[uint]) (uint, bool) {
 	// 👇 can't insert if not in a field or if the field is full
 	fld, ok := cell.FindFld()
 	if !ok || fld.Cells[len(fld.Cells)-1].Char > 0x40 {
@@ -292,7 +391,7 @@ func (k *Keyboard) keyinsert(cell *Cell, char byte, dfltAddr uint, deltas *utils
 	for ix = len(fld.Cells) - 1; ix > iy; ix-- {
 		fld.Cells[ix].Char = fld.Cells[ix-1].Char
 	}
-	cell.Char = conv.A2E(char)
+	cell.Char = e
 	// 👇 indicate ALL the cells that changed
 	addr, _ := cell.GetFldAddr()
 	for ix = iy; ix < len(fld.Cells); ix++ {
@@ -302,12 +401,12 @@ func (k *Keyboard) keyinsert(cell *Cell, char byte, dfltAddr uint, deltas *utils
 	return k.emu.Buf.WrappingSeek(int(addr) + iy + 1), true
 }
 
-func (k *Keyboard) keyinover(cell *Cell, char byte, dfltAddr uint) (uint, bool) {
-	cell.Char = conv.A2E(char)
+func (k *Keyboard) keyinover(cell *Cell, e byte, dfltAddr uint, wrap bool) (uint, bool) {
+	cell.Char = e
 	// 👇 if the next cell is a field start with autoskip, tab to next Fld
 	next, addr := k.emu.Buf.GetNext()
 	if next.IsFldStart() {
-		if next.Attrs.Autoskip {
+		if next.Attrs.Autoskip || wrap {
 			return k.tab(+1, dfltAddr)
 		}
 		// 👇 don't advance if not autoskip
@@ -317,6 +416,38 @@ func (k *Keyboard) keyinover(cell *Cell, char byte, dfltAddr uint) (uint, bool)
 	return k.emu.Buf.MustSeek(addr), true
 }
 
+// 🟦 NEW LINE
+
+func (k *Keyboard) newline(dfltAddr uint) (uint, bool) {
+	cols := k.emu.Cfg.Cols
+	start := k.emu.Buf.WrapAddr(int((dfltAddr/cols + 1) * cols))
+	// 👇 an unformatted screen has no protected areas to skip
+	if len(k.emu.Flds.Flds) == 0 {
+		return k.emu.Buf.MustSeek(start), true
+	}
+	// 👇 first unprotected character position from the next line on
+	for ix := 0; ix < int(k.emu.Buf.Len()); ix++ {
+		cell, addr := k.emu.Buf.WrappingPeek(int(start) + ix)
+		if !cell.IsFldStart() && !cell.Attrs.Protected {
+			return k.emu.Buf.MustSeek(addr), true
+		}
+	}
+	// 👇 looked everywhere and couldn't find it!
+	return dfltAddr, false
+}
+
+// 🟦 RESET
+
+// 👇 clear any input inhibit (eg: X PROG) and leave insert mode
+func (k *Keyboard) reset() {
+	k.emu.State.Patch(types.Patch{
+		Error:   utils.BoolPtr(false),
+		Insert:  utils.BoolPtr(false),
+		Locked:  utils.BoolPtr(k.emu.Dbg.Paused()),
+		Message: utils.StringPtr(""),
+	})
+}
+
 // 🟦 TAB
 
 func (k *Keyboard) tab(dir int, start uint) (uint, bool) {
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"emulator/types"
+	"emulator/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keyboardImg = []string{
+	/*                 1         2         3         4 */
+	/*        1234567890123456789012345678901234567890 */
+	/* 01 */ "         ¶Test screen                   ",
+	/* 02 */ "                                        ",
+	/* 03 */ "¶First name ?■          ¶               ",
+	/* 04 */ "¶Last name  ?■          ¶               ",
+	/* 05 */ "                                        ",
+	/* 06 */ "¶Number     ?■          ¶               ",
+	/* 07 */ "                                        ",
+	/* 08 */ "                                        ",
+	/* 09 */ "                                        ",
+	/* 10 */ "                                        ",
+	/* 11 */ "                                        ",
+	/* 12 */ "                             ¶Test # 46b",
+}
+
+var keyboardAttrs = MockAttrsMap{
+	{6, 14}: {Numeric: true},
+}
+
+func TestKeyboardPaste(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	stream := MockStream(types.EW, types.WCC{}, keyboardImg, keyboardAttrs)
+	emu.Bus.PubOutbound(stream)
+	renders := 0
+	emu.Bus.SubRenderDeltas(func(_ *utils.Stack[uint]) {
+		renders++
+	})
+
+	t.Run("paste wraps and skips protected areas", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15))})
+		emu.Bus.PubPaste("John Jacob Smith")
+		assert.Equal(t, "John Jacob", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 15), emu.Cfg.RC2Addr(3, 24), false))
+		assert.Equal(t, " Smith", emu.Cells.Copy(emu.Cfg.RC2Addr(4, 15), emu.Cfg.RC2Addr(4, 24), false))
+		assert.Equal(t, emu.Cfg.RC2Addr(4, 21), emu.State.Status.CursorAt)
+		assert.Equal(t, 1, renders, "paste is rendered in one batch")
+	})
+
+	t.Run("newline moves to next unprotected position", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15))})
+		emu.Bus.PubPaste("Jane\nDoe\n42")
+		assert.Equal(t, "Jane Jacob", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 15), emu.Cfg.RC2Addr(3, 24), false))
+		assert.Equal(t, "Doeith", emu.Cells.Copy(emu.Cfg.RC2Addr(4, 15), emu.Cfg.RC2Addr(4, 24), false))
+		assert.Equal(t, "42", emu.Cells.Copy(emu.Cfg.RC2Addr(6, 15), emu.Cfg.RC2Addr(6, 24), false))
+	})
+
+	t.Run("numeric field rejects letters", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(6, 15))})
+		alarm := false
+		emu.Bus.SubStatus(func(stat *types.Status) {
+			alarm = alarm || stat.Alarm
+		})
+		emu.Bus.PubPaste("9x")
+		assert.Equal(t, "92", emu.Cells.Copy(emu.Cfg.RC2Addr(6, 15), emu.Cfg.RC2Addr(6, 24), false))
+		assert.True(t, alarm)
+	})
+
+	// 🔥 unlike paste, which wraps on as the first case shows
+
+	t.Run("typing stops at a field end that is not autoskip", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 24))})
+		emu.Bus.PubKeystroke(types.Keystroke{Code: "KeyX", Key: "X"})
+		assert.Equal(t, "X", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 24), emu.Cfg.RC2Addr(3, 24), false))
+		assert.Equal(t, emu.Cfg.RC2Addr(3, 24), emu.State.Status.CursorAt)
+	})
+
+	t.Run("a full field takes no insertion", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15)), Insert: utils.BoolPtr(true)})
+		emu.Bus.PubPaste("Ann")
+		emu.State.Patch(types.Patch{Insert: utils.BoolPtr(false)})
+		assert.Equal(t, "Jane JacoX", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 15), emu.Cfg.RC2Addr(3, 24), false))
+	})
+
+	t.Run("insert mode shifts the field as typing does", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15))})
+		emu.Bus.PubPaste("Ann       ")
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15)), Insert: utils.BoolPtr(true)})
+		emu.Bus.PubPaste("Mary ")
+		emu.State.Patch(types.Patch{Insert: utils.BoolPtr(false)})
+		assert.Equal(t, "Mary Ann", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 15), emu.Cfg.RC2Addr(3, 24), false))
+		assert.Equal(t, emu.Cfg.RC2Addr(3, 20), emu.State.Status.CursorAt)
+	})
+}
+
+func TestKeyboardCodePage(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{}, keyboardImg, keyboardAttrs))
+	alarm := false
+	emu.Bus.SubStatus(func(stat *types.Status) {
+		alarm = alarm || stat.Alarm
+	})
+
+	t.Run("a rune the code page lacks sounds the alarm", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(3, 15))})
+		emu.Bus.PubPaste("5€")
+		assert.Equal(t, "5", emu.Cells.Copy(emu.Cfg.RC2Addr(3, 15), emu.Cfg.RC2Addr(3, 24), false))
+		assert.True(t, alarm)
+	})
+
+	t.Run("paste and typing use the active code page", func(t *testing.T) {
+		emu.Cfg.CodePage = "273"
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(emu.Cfg.RC2Addr(4, 15))})
+		emu.Bus.PubPaste("Jürgen")
+		emu.Bus.PubKeystroke(types.Keystroke{Code: "Quote", Key: "Ä"})
+		assert.Equal(t, "JürgenÄ", emu.Cells.Copy(emu.Cfg.RC2Addr(4, 15), emu.Cfg.RC2Addr(4, 24), false))
+		assert.Equal(t, byte(0x4a), emu.Buf.MustPeek(emu.Cfg.RC2Addr(4, 21)).Char)
+	})
+}
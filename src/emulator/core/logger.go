@@ -1,11 +1,12 @@
 package core
 
 import (
-	"bytes"
 	"emulator/conv"
+	"emulator/datastream"
 	"emulator/types"
 	"emulator/utils"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -18,10 +19,13 @@ import (
 // TODO 🔥 I never wanted all this in one file, but putting it in its
 // own package causes a cyclic dependency only a REALLY ugly
 // compromise removes. So this is the least-bad (so far) choice.
+// At least the parsing is now shared with Consumer via datastream.
 
 // 🔥 Most logging avoids blocking the main thread by using Go routines
 
 type Logger struct {
+	w io.Writer
+
 	emu *Emulator // 👈 back pointer to all common components
 }
 
@@ -30,6 +34,7 @@ type Logger struct {
 func NewLogger(emu *Emulator) *Logger {
 	l := new(Logger)
 	l.emu = emu
+	l.w = os.Stdout
 	// 👇 subscriptions
 	l.emu.Bus.SubClose(l.close)
 	l.emu.Bus.SubInitialize(l.initialize)
@@ -94,6 +99,31 @@ func (l *Logger) wcc(wcc types.WCC) {
 	}()
 }
 
+// ---------------------------------------------------------------------------
+// 🟦 List records on demand (eg: for cmd/decode)
+// ---------------------------------------------------------------------------
+
+// 👇 a Logger that subscribes to nothing, and only writes when asked
+func NewListing(emu *Emulator, w io.Writer) *Logger {
+	l := new(Logger)
+	l.emu = emu
+	l.w = w
+	return l
+}
+
+// 🔥 unlike logging, listing is synchronous, so records stay in order
+
+func (l *Logger) ListInbound(chars []byte, hints PubInboundHints) {
+	l.logInbound(chars, hints)
+}
+
+func (l *Logger) ListOutbound(chars []byte) {
+	if out, _ := datastream.DecodeOutbound(chars); out.WCC != nil {
+		l.logWCC(*out.WCC)
+	}
+	l.logOutbound(chars)
+}
+
 // ---------------------------------------------------------------------------
 // 🟦 Dump a slice of bytes
 // ---------------------------------------------------------------------------
@@ -192,7 +222,7 @@ func (l *Logger) logBuffer() {
 					} else {
 						str := " "
 						if cell.Char > 0x40 {
-							str = string(conv.E2Rune(cell.Attrs.LCID, cell.Char))
+							str = string(conv.E2RuneCP(l.emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char))
 						}
 						if cell.Attrs.CharAttr {
 							str = fmt.Sprintf("%s%s", text.FgYellow.Sprint(str), text.FgWhite.Sprint("\u200b"))
@@ -331,14 +361,11 @@ func (l *Logger) logInbound(chars []byte, hints PubInboundHints) {
 // ---------------------------------------------------------------------------
 
 func (l *Logger) logInboundRB(chars []byte) {
-	// 👇 convert into a stream for convenience
-	slice, _, ok := bytes.Cut(chars, types.LT)
-	in := NewOutbound(utils.Ternary(ok, slice, chars), l.emu.Bus)
-	char := in.MustNext()
-	aid := types.AID(char)
+	// 👇 decode the record, as far as we can
+	in, _ := datastream.DecodeInbound(chars)
 
 	// 👇 create table
-	t := l.newTable(text.FgHiGreen, fmt.Sprintf("%s: %s Inbound RB", aid, l.emu.Buf.Mode()))
+	t := l.newTable(text.FgHiGreen, fmt.Sprintf("%s: %s Inbound RB", in.AID, l.emu.Buf.Mode()))
 	defer t.Render()
 
 	// 👇 table headers
@@ -348,9 +375,7 @@ func (l *Logger) logInboundRB(chars []byte) {
 	})
 
 	// 👇 one row just for the cursor
-	raw := in.MustNextSlice(2)
-	cursorAt := conv.Bytes2Addr(raw)
-	row, col := l.emu.Cfg.Addr2RC(cursorAt)
+	row, col := l.emu.Cfg.Addr2RC(in.Cursor)
 	t.AppendRow(table.Row{"IC", row, col})
 
 	// 👇 we will aggregate data delimited by SF and SFE's
@@ -378,34 +403,30 @@ func (l *Logger) logInboundRB(chars []byte) {
 		return data
 	}
 
-	// 👇 look at each byte to see if it is an order
-	for in.HasNext() {
-		char := in.MustNext()
-		order := types.Order(char)
-		switch order {
+	// 👇 look at each order
+	for _, order := range in.Orders {
+		switch order.Order {
 
 		case types.SA:
 			data = flush(data)
-			chars := in.MustNextSlice(2)
-			attrs := types.NewExtendedAttrs(chars)
-			appendAttrs(order, attrs)
+			attrs := types.NewExtendedAttrs(order.Pairs.Bytes())
+			appendAttrs(order.Order, attrs)
 
 		case types.SF:
 			data = flush(data)
-			raw := in.MustNext()
-			attrs := types.NewBasicAttrs(raw)
-			appendAttrs(order, attrs)
+			attrs := types.NewBasicAttrs(order.Attr)
+			appendAttrs(order.Order, attrs)
 
 		case types.SFE:
 			data = flush(data)
-			count := in.MustNext()
-			raw := in.MustNextSlice(int(count) * 2)
-			attrs := types.NewExtendedAttrs(raw)
-			appendAttrs(order, attrs)
+			attrs := types.NewExtendedAttrs(order.Pairs.Bytes())
+			appendAttrs(order.Order, attrs)
 
-		default:
-			data = append(data, conv.E2A(char))
-			addr++
+		case datastream.DATA:
+			for _, char := range order.Chars {
+				data = append(data, conv.E2A(char))
+				addr++
+			}
 
 		}
 	}
@@ -420,14 +441,11 @@ func (l *Logger) logInboundRB(chars []byte) {
 // ---------------------------------------------------------------------------
 
 func (l *Logger) logInboundRM(chars []byte) {
-	// 👇 convert into a stream for convenience
-	slice, _, ok := bytes.Cut(chars, types.LT)
-	in := NewOutbound(utils.Ternary(ok, slice, chars), l.emu.Bus)
-	char := in.MustNext()
-	aid := types.AID(char)
+	// 👇 decode the record, as far as we can
+	in, _ := datastream.DecodeInbound(chars)
 
 	// 👇 create table
-	t := l.newTable(text.FgHiGreen, fmt.Sprintf("%s: %s Inbound RM/RMA", aid, l.emu.Buf.Mode()))
+	t := l.newTable(text.FgHiGreen, fmt.Sprintf("%s: %s Inbound RM/RMA", in.AID, l.emu.Buf.Mode()))
 	defer t.Render()
 
 	// 👇 table headers
@@ -437,9 +455,7 @@ func (l *Logger) logInboundRM(chars []byte) {
 	})
 
 	// 👇 one row just for the cursor
-	raw := in.MustNextSlice(2)
-	cursorAt := conv.Bytes2Addr(raw)
-	row, col := l.emu.Cfg.Addr2RC(cursorAt)
+	row, col := l.emu.Cfg.Addr2RC(in.Cursor)
 	t.AppendRow(table.Row{"IC", row, col})
 
 	// 👇 we will aggregate data delimited by SBA's
@@ -463,28 +479,26 @@ func (l *Logger) logInboundRM(chars []byte) {
 		return data
 	}
 
-	// 👇 look at each byte to see if it is an order
-	for in.HasNext() {
-		char := in.MustNext()
-		order := types.Order(char)
-		switch order {
+	// 👇 look at each order
+	for _, order := range in.Orders {
+		switch order.Order {
 
 		case types.SA:
 			data = flush(data)
-			chars := in.MustNextSlice(2)
-			attrs := types.NewExtendedAttrs(chars)
-			appendAttrs(order, attrs)
+			attrs := types.NewExtendedAttrs(order.Pairs.Bytes())
+			appendAttrs(order.Order, attrs)
 
 		case types.SBA:
 			data = flush(data)
-			raw := in.MustNextSlice(2)
-			addr = conv.Bytes2Addr(raw)
+			addr = order.Addr
 			row, col = l.emu.Cfg.Addr2RC(addr)
 			t.AppendRow(table.Row{"SBA", row, col, ""})
 
-		default:
-			data = append(data, conv.E2A(char))
-			addr++
+		case datastream.DATA:
+			for _, char := range order.Chars {
+				data = append(data, conv.E2A(char))
+				addr++
+			}
 
 		}
 	}
@@ -498,8 +512,8 @@ func (l *Logger) logInboundRM(chars []byte) {
 // ---------------------------------------------------------------------------
 
 func (l *Logger) logInboundShort(chars []byte) {
-	aid := types.AID(chars[0])
-	fmt.Printf("🐞 %s Short Read\n", aid)
+	in, _ := datastream.DecodeInbound(chars)
+	fmt.Fprintf(l.w, "🐞 %s Short Read\n", in.AID)
 }
 
 // ---------------------------------------------------------------------------
@@ -512,24 +526,19 @@ func (l *Logger) logInboundWSF(chars []byte) {
 	t := l.newTable(text.FgHiGreen, ("Inbound WSF"))
 	defer t.Render()
 
-	// 👇 convert into a stream for convenience
-	slice, _, ok := bytes.Cut(chars, types.LT)
-	in := NewOutbound(utils.Ternary(ok, slice, chars), l.emu.Bus)
-
-	// 👇 eat the AID
-	in.Next()
+	// 👇 decode the record, as far as we can
+	in, _ := datastream.DecodeInbound(chars)
 
 	// 👇 table rows
 	t.AppendHeader(table.Row{"ID", "Type", "Info"})
 	t.SetColumnConfigs([]table.ColumnConfig{
 		{Number: 3, Transformer: l.wrap(60), WidthMax: 60, WidthMin: 80},
 	})
-	sflds := SFldsFromStream(in)
 
-	for _, sfld := range sflds {
+	for _, sfld := range in.SFlds {
 		switch {
 
-		case sfld.ID == types.QUERY_REPLY:
+		case sfld.ID == types.QUERY_REPLY && len(sfld.Info) > 0:
 			qcode := types.QCode(sfld.Info[0])
 			t.AppendRow(table.Row{sfld.ID, qcode, fmt.Sprintf("% 02x", sfld.Info[1:])})
 
@@ -545,26 +554,14 @@ func (l *Logger) logInboundWSF(chars []byte) {
 // ---------------------------------------------------------------------------
 
 func (l *Logger) logOutbound(chars []byte) {
-	// 👇 analyze the commands in the stream
-	out := NewOutbound(chars, l.emu.Bus)
-	char := out.MustNext()
-	cmd := types.Command(char)
+	// 👇 decode the commands in the stream, as far as we can
+	out, _ := datastream.DecodeOutbound(chars)
 	// 👇 now we can analyze commands with data
-	switch cmd {
-
-	case types.EW:
-		if _, ok := out.Next(); ok { // 👈 eat the WCC
-			l.logOutboundOrders(out, cmd, text.FgYellow)
-		}
+	switch out.Cmd {
 
-	case types.EWA:
-		if _, ok := out.Next(); ok { // 👈 eat the WCC
-			l.logOutboundOrders(out, cmd, text.FgYellow)
-		}
-
-	case types.W:
-		if _, ok := out.Next(); ok { // 👈 eat the WCC
-			l.logOutboundOrders(out, cmd, text.FgYellow)
+	case types.EW, types.EWA, types.W:
+		if out.WCC != nil {
+			l.logOutboundOrders(out, text.FgYellow)
 		}
 
 	case types.WSF:
@@ -576,8 +573,8 @@ func (l *Logger) logOutbound(chars []byte) {
 // 🟪 ...orders
 // ---------------------------------------------------------------------------
 
-func (l *Logger) logOutboundOrders(out *Outbound, cmd types.Command, color text.Color) {
-	t := l.newTable(color, fmt.Sprintf("%s Outbound Orders\nNOTE: EUA and RA orders are listed in start/stop pairs", cmd))
+func (l *Logger) logOutboundOrders(out *datastream.Outbound, color text.Color) {
+	t := l.newTable(color, fmt.Sprintf("%s Outbound Orders\nNOTE: EUA and RA orders are listed in start/stop pairs", out.Cmd))
 	defer t.Render()
 	var addr uint
 	fldAttrs := types.NewDefaultAttrs()
@@ -603,71 +600,58 @@ func (l *Logger) logOutboundOrders(out *Outbound, cmd types.Command, color text.
 		"LCID",
 	})
 
-	// 👇 look at each byte to see if it is an order
-	for out.HasNext() {
-		char := out.MustNext()
-		order := types.Order(char)
-		switch order {
+	// 👇 look at each order
+	for _, order := range out.Orders {
+		switch order.Order {
 
 		case types.EUA:
-			raw := out.MustNextSlice(2)
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, ' ')
-			addr = conv.Bytes2Addr(raw)
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, ' ')
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, ' ')
+			addr = order.Addr
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, ' ')
 
 		case types.GE:
-			char := out.MustNext()
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, conv.E2A(char))
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, conv.E2A(order.Chars[0]))
 
 		case types.IC:
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, ' ')
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, ' ')
 
 		case types.MF:
-			count := out.MustNext()
-			raw := out.MustNextSlice(int(count) * 2)
-			fldAttrs = types.NewExtendedAttrs(raw)
-			l.logOutboundOrdersWithAttrs(t, order, addr, fldAttrs, false)
+			fldAttrs = types.NewExtendedAttrs(order.Pairs.Bytes())
+			l.logOutboundOrdersWithAttrs(t, order.Order, addr, fldAttrs, false)
 			addr++
 
 		case types.PT:
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, ' ')
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, ' ')
 
 		case types.RA:
-			raw := out.MustNextSlice(2)
-			char := out.MustNext()
-			if types.Order(char) == types.GE {
-				char = out.MustNext()
-				l.logOutboundOrdersWithoutAttrs(t, types.GE, addr, conv.E2A(char))
+			char := conv.E2A(order.Chars[0])
+			if order.GE {
+				l.logOutboundOrdersWithoutAttrs(t, types.GE, addr, char)
 			}
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, conv.E2A(char))
-			addr = conv.Bytes2Addr(raw)
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, conv.E2A(char))
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, char)
+			addr = order.Addr
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, char)
 
 		case types.SA:
-			chars := out.MustNextSlice(2)
-			fldAttrs = types.NewModifiedAttrs(fldAttrs, chars)
-			l.logOutboundOrdersWithAttrs(t, order, addr, fldAttrs, false)
+			fldAttrs = types.NewModifiedAttrs(fldAttrs, order.Pairs.Bytes())
+			l.logOutboundOrdersWithAttrs(t, order.Order, addr, fldAttrs, false)
 
 		case types.SBA:
-			raw := out.MustNextSlice(2)
-			addr = conv.Bytes2Addr(raw)
-			l.logOutboundOrdersWithoutAttrs(t, order, addr, 0)
+			addr = order.Addr
+			l.logOutboundOrdersWithoutAttrs(t, order.Order, addr, 0)
 
 		case types.SF:
-			raw := out.MustNext()
-			fldAttrs = types.NewBasicAttrs(raw)
-			l.logOutboundOrdersWithAttrs(t, order, addr, fldAttrs, true)
+			fldAttrs = types.NewBasicAttrs(order.Attr)
+			l.logOutboundOrdersWithAttrs(t, order.Order, addr, fldAttrs, true)
 			addr++
 
 		case types.SFE:
-			count := out.MustNext()
-			raw := out.MustNextSlice(int(count) * 2)
-			fldAttrs = types.NewExtendedAttrs(raw)
-			l.logOutboundOrdersWithAttrs(t, order, addr, fldAttrs, true)
+			fldAttrs = types.NewExtendedAttrs(order.Pairs.Bytes())
+			l.logOutboundOrdersWithAttrs(t, order.Order, addr, fldAttrs, true)
 			addr++
 
-		default:
-			addr++
+		case datastream.DATA:
+			addr += uint(len(order.Chars))
 
 		}
 	}
@@ -710,15 +694,15 @@ func (l *Logger) logOutboundOrdersWithoutAttrs(t table.Writer, cmd any, addr uin
 // 🟪 ...WSF
 // ---------------------------------------------------------------------------
 
-func (l *Logger) logOutboundWSF(out *Outbound, color text.Color) {
+func (l *Logger) logOutboundWSF(out *datastream.Outbound, color text.Color) {
 	t := l.newTable(color, "Outbound WSF")
 	defer t.Render()
 
 	// 👇 table rows
-	t.AppendHeader(table.Row{"ID", "Info"})
-	sflds := SFldsFromStream(out)
-	for _, sfld := range sflds {
-		t.AppendRow(table.Row{sfld.ID, fmt.Sprintf("% #x", sfld.Info)})
+	t.AppendHeader(table.Row{"ID", "PID", "Info"})
+	for _, sfld := range out.SFlds {
+		pid := utils.Ternary(sfld.HasPID, fmt.Sprintf("%#02x", sfld.PID), "")
+		t.AppendRow(table.Row{sfld.ID, pid, fmt.Sprintf("% #x", sfld.Info)})
 	}
 }
 
@@ -816,7 +800,7 @@ func (l *Logger) logProbe(addr uint) {
 func (l *Logger) logTrace(topic Topic, handler interface{}) {
 	if !strings.Contains(topic.String(), "tick") /* 🔥 suppressed ?? */ && false {
 		pkg, nm := utils.GetFuncName(handler)
-		fmt.Printf("🐞 topic %s -> func %s() in %s\n", topic, nm, pkg)
+		fmt.Fprintf(l.w, "🐞 topic %s -> func %s() in %s\n", topic, nm, pkg)
 	}
 }
 
@@ -862,7 +846,7 @@ func (l *Logger) boolean(flag bool) string {
 
 func (l *Logger) newTable(color text.Color, title string) table.Writer {
 	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
+	t.SetOutputMirror(l.w)
 	style := table.StyleBold
 	style.Color = table.ColorOptions{
 		Border:    text.Colors{color, text.Bold},
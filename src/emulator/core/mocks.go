@@ -53,6 +53,7 @@ func MockEmulator(rows, cols uint) *Emulator {
 			0xfe: "#c0c0c0",
 			0xff: "#e2e2e9"},
 		Cols:         cols,
+		DPI:          dpi,
 		FontHeight:   fontHeight,
 		FontSize:     fontSize,
 		FontWidth:    fontWidth,
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"regexp"
+	"strings"
+)
+
+// 🟧 Respond to mouse (pointer) input
+
+// 👁️ All page references to:
+// https://bitsavers.org/pdf/ibm/3270/GA23-0059-07_3270_Data_Stream_Programmers_Reference_199206.pdf
+
+// 👁️ Keyboard Operations pp 7-10 to 7-15 for cursor select
+
+type Mouse struct {
+	emu *Emulator // 👈 back pointer to all common components
+}
+
+// 👇 hotspots are "PFnn" and "Fnn=" as popularized by x3270
+var hotspot = regexp.MustCompile(`^(?:PF([0-9]{1,2})(?:=.*)?|F([0-9]{1,2})=.*)$`)
+
+// 🟦 Constructor
+
+func NewMouse(emu *Emulator) *Mouse {
+	m := new(Mouse)
+	m.emu = emu
+	// 👇 subscriptions
+	m.emu.Bus.SubPointer(m.pointer)
+	return m
+}
+
+// 🟦 Dispatch action per event type
+
+func (m *Mouse) pointer(ptr types.Pointer) {
+	switch ptr.Type {
+
+	case "click":
+		m.click(ptr)
+
+	case "dblclick":
+		m.dblclick(ptr)
+
+	case "wheel":
+		m.wheel(ptr)
+
+	}
+}
+
+// 🟦 CLICK
+
+func (m *Mouse) click(ptr types.Pointer) {
+	addr, ok := m.emu.Scr.AddrAt(ptr.X, ptr.Y)
+	if !ok {
+		return
+	}
+	// 👇 position the cursor exactly as the arrow keys would
+	// 🔥 via the bus, so that a trace can record it
+	m.emu.Bus.PubCursor(addr)
+	// 👇 clicking on a hotspot is the same as pressing its PF key
+	// 🔥 but not until the host responds, else a double click sends two
+	if aid, ok := m.hotspot(addr); ok && !m.emu.State.Status.Locked {
+		m.emu.Bus.PubRM(aid)
+	}
+}
+
+// 🟦 DBLCLICK
+
+func (m *Mouse) dblclick(ptr types.Pointer) {
+	addr, ok := m.emu.Scr.AddrAt(ptr.X, ptr.Y)
+	if !ok {
+		return
+	}
+	// 🔥 the click that preceded us has already moved the cursor
+	if !m.cursorSelect(addr) {
+		m.emu.State.Patch(types.Patch{Alarm: utils.BoolPtr(true)})
+	}
+}
+
+// 🟦 WHEEL
+
+func (m *Mouse) wheel(ptr types.Pointer) {
+	// 🔥 wheels fire in bursts, so ignore them until the host responds
+	if m.emu.State.Status.Locked {
+		return
+	}
+	switch {
+
+	case ptr.DeltaY < 0:
+		m.emu.Bus.PubRM(utils.Ternary(m.emu.Cfg.WheelUp != 0, m.emu.Cfg.WheelUp, types.PF7))
+
+	case ptr.DeltaY > 0:
+		m.emu.Bus.PubRM(utils.Ternary(m.emu.Cfg.WheelDown != 0, m.emu.Cfg.WheelDown, types.PF8))
+
+	}
+}
+
+// 🟦 Helpers
+
+// 👇 act on the designator character of a selector-pen detectable field
+func (m *Mouse) cursorSelect(addr uint) bool {
+	cell := m.emu.Buf.MustPeek(addr)
+	fld, ok := cell.FindFld()
+	if !ok || len(fld.Cells) <= 1 {
+		return false
+	}
+	sf := fld.Cells[0]
+	// 👇 intensified fields are detectable too
+	if !sf.Attrs.Detectable && !sf.Attrs.Highlight {
+		return false
+	}
+	home := fld.Cells[1]
+	fldAddr, _ := sf.GetFldAddr()
+	homeAddr := m.emu.Buf.WrapAddr(int(fldAddr) + 1)
+	deltas := utils.NewStack[uint](1)
+
+	switch home.Char {
+
+	case conv.A2E('?'):
+		home.Char = conv.A2E('>')
+		sf.Attrs.MDT = true
+		deltas.Push(homeAddr)
+
+	case conv.A2E('>'):
+		home.Char = conv.A2E('?')
+		sf.Attrs.MDT = false
+		deltas.Push(homeAddr)
+
+	case conv.A2E('&'):
+		sf.Attrs.MDT = true
+		m.emu.Bus.PubRM(types.ENTER)
+
+	case 0x00, 0x40:
+		m.emu.Bus.PubRM(types.SELPEN)
+
+	default:
+		return false
+	}
+
+	if !deltas.Empty() {
+		m.emu.Bus.PubRenderDeltas(deltas)
+	}
+	return true
+}
+
+// 👇 look for a "PFnn" or "Fnn=" word under the pointer
+func (m *Mouse) hotspot(addr uint) (types.AID, bool) {
+	cell := m.emu.Buf.MustPeek(addr)
+	// 🔥 clicking in an input field only ever positions the cursor
+	if !cell.Attrs.Protected && !cell.IsFldStart() {
+		return 0, false
+	}
+	// 👇 gather the text of the row the pointer is on
+	row, col := m.emu.Cfg.Addr2RC(addr)
+	start := m.emu.Cfg.RC2Addr(row, 1)
+	runes := make([]rune, m.emu.Cfg.Cols)
+	for ix := range runes {
+		cell := m.emu.Buf.MustPeek(start + uint(ix))
+		visible := !cell.IsFldStart() && !cell.Attrs.Hidden && cell.Char > 0x40
+		runes[ix] = utils.Ternary(visible, conv.E2RuneCP(m.emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char), ' ')
+	}
+	// 👇 find the word that surrounds the pointer
+	lo, hi := int(col-1), int(col-1)
+	if runes[lo] == ' ' {
+		return 0, false
+	}
+	for lo > 0 && runes[lo-1] != ' ' {
+		lo--
+	}
+	for hi < len(runes)-1 && runes[hi+1] != ' ' {
+		hi++
+	}
+	word := strings.TrimRight(strings.ToUpper(string(runes[lo:hi+1])), ".,;:")
+	matches := hotspot.FindStringSubmatch(word)
+	if matches == nil {
+		return 0, false
+	}
+	num := utils.Ternary(matches[1] != "", matches[1], matches[2])
+	aid := types.AIDOf("F"+strings.TrimLeft(num, "0"), false, false, false)
+	return aid, aid.PFx()
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var mouseImg = []string{
+	/*                 1         2         3         4 */
+	/*        1234567890123456789012345678901234567890 */
+	/* 01 */ "         ¶Test screen                   ",
+	/* 02 */ "                                        ",
+	/* 03 */ "¶What is your name ?■                  ¶",
+	/* 04 */ "                                        ",
+	/* 05 */ "■?Option one       ¶                    ",
+	/* 06 */ "                                        ",
+	/* 07 */ "■?Option two       ¶                    ",
+	/* 08 */ "                                        ",
+	/* 09 */ "■?Option three     ¶                    ",
+	/* 10 */ "                                        ",
+	/* 11 */ "                                        ",
+	/* 12 */ "¶PF3=Exit  F7=Up  PF8                   ",
+}
+
+var mouseAttrs = MockAttrsMap{
+	{5, 1}: {Highlight: true},
+	{7, 1}: {Detectable: true},
+}
+
+// 👇 the center of the box at row, col
+func mousePointer(emu *Emulator, kind string, row, col uint) types.Pointer {
+	box := NewBox(row, col, emu.Cfg)
+	return types.Pointer{Type: kind, X: box.X + box.W/2, Y: box.Y + box.H/2}
+}
+
+func TestMouseClick(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	stream := MockStream(types.EW, types.WCC{Unlock: true}, mouseImg, mouseAttrs)
+	emu.Bus.PubOutbound(stream)
+	var aid types.AID
+	emu.Bus.SubInbound(func(chars []byte, _ PubInboundHints) {
+		aid = types.AID(chars[0])
+	})
+	var moved []uint
+	emu.Bus.SubCursor(func(addr uint) {
+		moved = append(moved, addr)
+	})
+
+	t.Run("click in input field positions cursor", func(t *testing.T) {
+		emu.Bus.PubPointer(mousePointer(emu, "click", 3, 25))
+		assert.Equal(t, emu.Cfg.RC2Addr(3, 25), emu.State.Status.CursorAt)
+		// 👇 published, so that a trace records it
+		assert.Equal(t, []uint{emu.Cfg.RC2Addr(3, 25)}, moved)
+		assert.False(t, emu.State.Status.Protected)
+		assert.Equal(t, types.AID(0), aid)
+	})
+
+	t.Run("click on hotspots sends AID", func(t *testing.T) {
+		emu.Bus.PubPointer(mousePointer(emu, "click", 12, 4))
+		assert.Equal(t, types.PF3, aid)
+		assert.True(t, emu.State.Status.Protected)
+		emu.Bus.PubOutbound(stream)
+		emu.Bus.PubPointer(mousePointer(emu, "click", 12, 13))
+		assert.Equal(t, types.PF7, aid)
+		emu.Bus.PubOutbound(stream)
+		emu.Bus.PubPointer(mousePointer(emu, "click", 12, 19))
+		assert.Equal(t, types.PF8, aid)
+	})
+
+	t.Run("double click on a hotspot sends one AID", func(t *testing.T) {
+		emu.Bus.PubOutbound(stream)
+		var aids []types.AID
+		emu.Bus.SubInbound(func(chars []byte, _ PubInboundHints) {
+			aids = append(aids, types.AID(chars[0]))
+		})
+		// 👇 as the browser reports it
+		emu.Bus.PubPointer(mousePointer(emu, "click", 12, 4))
+		emu.Bus.PubPointer(mousePointer(emu, "click", 12, 4))
+		emu.Bus.PubPointer(mousePointer(emu, "dblclick", 12, 4))
+		assert.Equal(t, []types.AID{types.PF3}, aids)
+	})
+
+	t.Run("click outside screen is ignored", func(t *testing.T) {
+		cursorAt := emu.State.Status.CursorAt
+		emu.Bus.PubPointer(types.Pointer{Type: "click", X: 10000, Y: 10000})
+		assert.Equal(t, cursorAt, emu.State.Status.CursorAt)
+	})
+}
+
+func TestMouseCursorSelect(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	stream := MockStream(types.EW, types.WCC{Unlock: true}, mouseImg, mouseAttrs)
+	emu.Bus.PubOutbound(stream)
+	home := emu.Buf.MustPeek(emu.Cfg.RC2Addr(5, 2))
+	sf := emu.Buf.MustPeek(emu.Cfg.RC2Addr(5, 1))
+
+	emu.Bus.PubPointer(mousePointer(emu, "dblclick", 5, 5))
+	assert.Equal(t, conv.A2E('>'), home.Char, "? designator becomes >")
+	assert.True(t, sf.Attrs.MDT)
+
+	emu.Bus.PubPointer(mousePointer(emu, "dblclick", 5, 5))
+	assert.Equal(t, conv.A2E('?'), home.Char, "> designator becomes ?")
+	assert.False(t, sf.Attrs.MDT)
+
+	// 👇 detectable at normal intensity, from the display bits
+	emu.Bus.PubPointer(mousePointer(emu, "dblclick", 7, 5))
+	assert.Equal(t, conv.A2E('>'), emu.Buf.MustPeek(emu.Cfg.RC2Addr(7, 2)).Char)
+
+	// 👇 but not every field is detectable
+	emu.Bus.PubPointer(mousePointer(emu, "dblclick", 9, 5))
+	assert.Equal(t, conv.A2E('?'), emu.Buf.MustPeek(emu.Cfg.RC2Addr(9, 2)).Char)
+}
+
+func TestMouseWheel(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Cfg.WheelDown = types.PF20
+	stream := MockStream(types.EW, types.WCC{Unlock: true}, mouseImg, mouseAttrs)
+	var aids []types.AID
+	emu.Bus.SubInbound(func(chars []byte, _ PubInboundHints) {
+		aids = append(aids, types.AID(chars[0]))
+	})
+
+	emu.Bus.PubOutbound(stream)
+	emu.Bus.PubPointer(types.Pointer{Type: "wheel", DeltaY: -100})
+	emu.Bus.PubOutbound(stream)
+	emu.Bus.PubPointer(types.Pointer{Type: "wheel", DeltaY: 100})
+	// 🔥 keyboard is now locked so this is ignored
+	emu.Bus.PubPointer(types.Pointer{Type: "wheel", DeltaY: 100})
+
+	assert.Equal(t, []types.AID{types.PF7, types.PF20}, aids)
+}
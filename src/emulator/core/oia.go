@@ -0,0 +1,201 @@
+package core
+
+import (
+	"emulator/fonts"
+	"emulator/types"
+	"emulator/utils"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// 🟧 Operator Information Area, drawn by the screen in an extra
+//    row below the presentation space when configured
+
+// 👇 we follow the layout popularized by x3270, but anchor the
+//    right-hand indicators to the right edge to fit narrow screens
+
+// 🔥 the OIA symbols in 3270Medium have no code points, so we must
+//    find them by glyph name and rasterize their outlines ourselves
+
+type OIA struct {
+	buf    sfnt.Buffer
+	font   *sfnt.Font
+	glyphs map[string]sfnt.GlyphIndex
+}
+
+// 👇 a single glyph to draw, either a rune or a named OIA symbol
+type OIAGlyph struct {
+	Rune   rune
+	Symbol string
+}
+
+// 🟦 Constructor
+
+func NewOIA() *OIA {
+	o := new(OIA)
+	o.glyphs = make(map[string]sfnt.GlyphIndex)
+	f, err := sfnt.Parse(fonts.OIAFontEmbed)
+	if err != nil {
+		return o
+	}
+	o.font = f
+	// 👇 index the glyphs by name
+	for ix := 0; ix < f.NumGlyphs(); ix++ {
+		name, err := f.GlyphName(&o.buf, sfnt.GlyphIndex(ix))
+		if err == nil && name != "" {
+			o.glyphs[name] = sfnt.GlyphIndex(ix)
+		}
+	}
+	return o
+}
+
+// 🟦 Rendering functions
+
+// 👇 layout the OIA, one glyph per column, for the renderer to draw
+func (s *Screen) renderOIA(stat *types.Status) {
+	cfg := s.emu.Cfg
+	fg := cfg.CLUT[utils.Ternary(cfg.Monochrome, types.GREEN, types.WHITE)]
+	if cfg.OIAColor != "" {
+		fg = cfg.OIAColor
+	}
+	glyphs := make(map[uint]OIAGlyph)
+	text := func(col uint, str string) {
+		for _, r := range str {
+			glyphs[col] = OIAGlyph{Rune: r}
+			col++
+		}
+	}
+	symbol := func(col uint, name string) {
+		glyphs[col] = OIAGlyph{Symbol: name}
+	}
+	// 👇 readiness and connection to the host
+	symbol(1, "box4")
+	if stat.Connected {
+		symbol(2, "boxA")
+		symbol(3, "boxsolid")
+	} else {
+		symbol(2, "boxquestion")
+	}
+	// 👇 right-hand indicators
+	cursorCol := cfg.Cols - 6
+	protCol := cursorCol - 6
+	numCol := protCol - 4
+	insertCol := numCol - 2
+	// 👇 input inhibited
+	switch {
+
+	case stat.Waiting:
+		text(9, "X")
+		symbol(11, "clockleft")
+		symbol(12, "clockright")
+
+	case stat.Error:
+		// 🔥 truncate the message before it collides with the indicators
+		room := max(int(insertCol)-12, 0)
+		text(9, "X")
+		text(11, stat.Message[:min(len(stat.Message), room)])
+
+	case stat.Locked:
+		text(9, "X SYSTEM")
+
+	}
+	// 👇 keyboard state
+	if stat.Insert {
+		symbol(insertCol, "insert")
+	}
+	if stat.Numeric {
+		text(numCol, "NUM")
+	}
+	if stat.Protected {
+		text(protCol, "PROT")
+	}
+	// 👇 cursor position
+	r, c := cfg.Addr2RC(stat.CursorAt)
+	text(cursorCol, fmt.Sprintf("%03d/%03d", r, c))
+	// 👇 now we can draw all the glyphs at once
+	s.damage(s.renderer.DrawOIA(glyphs, fg))
+}
+
+func (r *RasterRenderer) DrawOIA(glyphs map[uint]OIAGlyph, color string) image.Rectangle {
+	cfg := r.emu.Cfg
+	row := NewBox(cfg.Rows+1, 1, cfg)
+	// 🔥 no room if the canvas wasn't sized for the OIA
+	bounds := image.Rect(0, int(row.Y), cfg.RGBA.Bounds().Dx(), int(row.Y+row.H))
+	if r.oia == nil || r.oia.font == nil || !bounds.In(cfg.RGBA.Bounds()) {
+		return image.Rectangle{}
+	}
+	// 👇 clear the row and draw the separator line
+	fg := utils.HexColor(color)
+	fill(cfg.RGBA, bounds, utils.HexColor(cfg.BgColor))
+	fill(cfg.RGBA, image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, bounds.Min.Y+1), fg)
+	// 👇 now we can rasterize all the glyphs at once
+	r.oia.rasterize(cfg, glyphs, bounds, image.NewUniform(fg))
+	return bounds
+}
+
+func (o *OIA) rasterize(cfg *types.Config, glyphs map[uint]OIAGlyph, bounds image.Rectangle, fg image.Image) {
+	dpi := utils.Ternary(cfg.DPI > 0, cfg.DPI, 72)
+	ppem := fixed.Int26_6(cfg.FontSize * dpi / 72 * 64)
+	r := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	r.DrawOp = draw.Src
+	for col, g := range glyphs {
+		// 🔥 don't draw off the edge of the canvas
+		if col < 1 || col > cfg.Cols {
+			continue
+		}
+		box := NewBox(cfg.Rows+1, col, cfg)
+		ix, ok := o.glyphs[g.Symbol]
+		if g.Symbol == "" {
+			ix, _ = o.font.GlyphIndex(&o.buf, g.Rune)
+			ok = ix != 0
+		}
+		if !ok {
+			continue
+		}
+		segments, err := o.font.LoadGlyph(&o.buf, ix, ppem, nil)
+		if err != nil {
+			continue
+		}
+		// 👇 origin is the glyph's baseline, relative to the OIA row
+		x := float32(box.X)
+		y := float32(box.Baseline - box.Y)
+		for _, seg := range segments {
+			switch seg.Op {
+
+			case sfnt.SegmentOpMoveTo:
+				r.MoveTo(x+fix2f(seg.Args[0].X), y+fix2f(seg.Args[0].Y))
+
+			case sfnt.SegmentOpLineTo:
+				r.LineTo(x+fix2f(seg.Args[0].X), y+fix2f(seg.Args[0].Y))
+
+			case sfnt.SegmentOpQuadTo:
+				r.QuadTo(
+					x+fix2f(seg.Args[0].X), y+fix2f(seg.Args[0].Y),
+					x+fix2f(seg.Args[1].X), y+fix2f(seg.Args[1].Y))
+
+			case sfnt.SegmentOpCubeTo:
+				r.CubeTo(
+					x+fix2f(seg.Args[0].X), y+fix2f(seg.Args[0].Y),
+					x+fix2f(seg.Args[1].X), y+fix2f(seg.Args[1].Y),
+					x+fix2f(seg.Args[2].X), y+fix2f(seg.Args[2].Y))
+
+			}
+		}
+		r.ClosePath()
+	}
+	// 👇 use the accumulated outlines as a mask over the foreground color
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	r.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+	draw.DrawMask(cfg.RGBA, bounds, fg, image.Point{}, mask, image.Point{}, draw.Over)
+}
+
+// 🟦 Helpers
+
+func fix2f(f fixed.Int26_6) float32 {
+	return float32(f) / 64
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"emulator/types"
+	"emulator/utils"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 count pixels in the OIA row that are neither background nor blank
+func oiaInk(emu *Emulator, fromCol, toCol uint) int {
+	bg := utils.HexColor(emu.Cfg.BgColor)
+	row := NewBox(emu.Cfg.Rows+1, fromCol, emu.Cfg)
+	end := NewBox(emu.Cfg.Rows+1, toCol, emu.Cfg)
+	count := 0
+	// 🔥 skip the separator line at the top of the row
+	for y := int(row.Y) + 1; y < int(row.Y+row.H); y++ {
+		for x := int(row.X); x < int(end.X+end.W); x++ {
+			if emu.Cfg.RGBA.RGBAAt(x, y) != bg {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestOIA(t *testing.T) {
+	// 👇 make a mock config with room for the OIA
+	cfg := *MockEmulator(12, 40).Cfg
+	box := NewBox(cfg.Rows+1, cfg.Cols, &cfg)
+	cfg.OIA = true
+	cfg.RGBA = image.NewRGBA(image.Rect(0, 0, int(box.X+box.W), int(box.Y+box.H)))
+	emu := NewEmulator(NewBus(), &cfg).Initialize()
+
+	t.Run("OIA font has the 3270 symbols", func(t *testing.T) {
+		for _, name := range []string{"box4", "boxA", "boxquestion", "boxsolid", "clockleft", "clockright", "insert"} {
+			_, ok := emu.Scr.renderer.(*RasterRenderer).oia.glyphs[name]
+			assert.True(t, ok, name)
+		}
+	})
+
+	t.Run("OIA drawn on status change", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(0)})
+		// 👇 separator line
+		assert.NotEqual(t, utils.HexColor(cfg.BgColor), cfg.RGBA.RGBAAt(0, int(box.Y)))
+		// 👇 readiness symbols and cursor position are always there
+		assert.Positive(t, oiaInk(emu, 1, 3))
+		assert.Positive(t, oiaInk(emu, 34, 40))
+		// 👇 but nothing is inhibited
+		assert.Zero(t, oiaInk(emu, 9, 16))
+		assert.Zero(t, oiaInk(emu, 22, 22))
+	})
+
+	t.Run("OIA shows insert and wait clock", func(t *testing.T) {
+		emu.State.Patch(types.Patch{Insert: utils.BoolPtr(true), Waiting: utils.BoolPtr(true)})
+		assert.Positive(t, oiaInk(emu, 9, 12))
+		assert.Positive(t, oiaInk(emu, 22, 22))
+		// 👇 and cleared again when the state changes
+		emu.State.Patch(types.Patch{Insert: utils.BoolPtr(false), Waiting: utils.BoolPtr(false)})
+		assert.Zero(t, oiaInk(emu, 9, 12))
+		assert.Zero(t, oiaInk(emu, 22, 22))
+	})
+
+	t.Run("OIA ignored when no room on canvas", func(t *testing.T) {
+		emu := MockEmulator(12, 40).Initialize()
+		emu.Cfg.OIA = true
+		assert.NotPanics(t, func() {
+			emu.Scr.renderOIA(emu.State.Status)
+		})
+	})
+}
@@ -43,6 +43,8 @@ func (p *Producer) attn(aid types.AID) {
 
 // 👁️ Query p 6-19
 func (p *Producer) q() {
+	// 👇 report the cell size as drawn, padding and all
+	cell := NewBox(1, 1, p.emu.Cfg)
 	in := NewInbound()
 	in.Put(byte(types.INBOUND))
 	// 👇 SUMMARY
@@ -61,9 +63,9 @@ func (p *Producer) q() {
 		types.IMPLICIT_PARTITION,
 	}).Put(in)
 	// 👇 then the rest
-	qr.NewUsableArea(p.emu.Cfg.Cols, p.emu.Cfg.Rows, p.emu.Cfg.FontWidth, p.emu.Cfg.FontHeight).Put(in)
+	qr.NewUsableArea(p.emu.Cfg.Cols, p.emu.Cfg.Rows, cell.W, cell.H).Put(in)
 	qr.NewAlphanumericPartitions(p.emu.Cfg.Cols, p.emu.Cfg.Rows).Put(in)
-	qr.NewCharacterSets(p.emu.Cfg.FontWidth, p.emu.Cfg.FontHeight).Put(in)
+	qr.NewCharacterSets(cell.W, cell.H).Put(in)
 	qr.NewColorSupport(p.emu.Cfg.Monochrome).Put(in)
 	qr.NewHighlighting().Put(in)
 	qr.NewReplyModes().Put(in)
@@ -79,16 +81,17 @@ func (p *Producer) q() {
 
 // 👁️ Query List p 6-19
 func (p *Producer) ql(qcodes []types.QCode) {
+	cell := NewBox(1, 1, p.emu.Cfg)
 	in := NewInbound()
 	in.Put(byte(types.INBOUND))
 	for _, qcode := range qcodes {
 		switch qcode {
 		case types.USABLE_AREA:
-			qr.NewUsableArea(p.emu.Cfg.Cols, p.emu.Cfg.Rows, p.emu.Cfg.FontWidth, p.emu.Cfg.FontHeight).Put(in)
+			qr.NewUsableArea(p.emu.Cfg.Cols, p.emu.Cfg.Rows, cell.W, cell.H).Put(in)
 		case types.ALPHANUMERIC_PARTITIONS:
 			qr.NewAlphanumericPartitions(p.emu.Cfg.Cols, p.emu.Cfg.Rows).Put(in)
 		case types.CHARACTER_SETS:
-			qr.NewCharacterSets(p.emu.Cfg.FontWidth, p.emu.Cfg.FontHeight).Put(in)
+			qr.NewCharacterSets(cell.W, cell.H).Put(in)
 		case types.COLOR_SUPPORT:
 			qr.NewColorSupport(p.emu.Cfg.Monochrome).Put(in)
 		case types.HIGHLIGHTING:
@@ -131,7 +134,12 @@ func (p *Producer) rm(aid types.AID) {
 	if !aid.ShortRead() {
 		cursorAt := p.emu.State.Status.CursorAt
 		in.PutSlice(conv.Addr2Bytes(cursorAt))
-		in.PutSlice(p.emu.Flds.RM())
+		// 👇 selector pen attention sends addresses but no data
+		if aid == types.SELPEN {
+			in.PutSlice(p.emu.Flds.SelPen())
+		} else {
+			in.PutSlice(p.emu.Flds.RM())
+		}
 		// 👇 frame boundary LT is last
 		in.PutSlice(types.LT)
 		p.emu.Bus.PubInbound(in.Bytes(), PubInboundHints{RM: true})
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"emulator/conv"
+	"emulator/datastream"
+	"emulator/types"
+	"emulator/utils"
+	"errors"
+	"fmt"
+)
+
+// 🟧 Program check: an outbound command the emulator rejects whole,
+//    leaving the screen untouched and the keyboard inhibited with
+//    X PROG nnn until the operator presses RESET, or the host
+//    starts afresh with an erase
+
+// 🔥 a real terminal would also send a negative response, but that
+//    needs TN3270E, which we don't negotiate -- so the host learns
+//    nothing, just as with any terminal that has no way to say so
+
+type ProgCheck struct {
+	// 👇 offset into the record of the offending byte
+	At   int
+	Cmd  types.Command
+	Code ProgCode
+	Msg  string
+}
+
+type ProgCode int
+
+// 👇 the codes a terminal shows in the OIA
+
+const (
+	PROG_750 ProgCode = 750 // 👈 invalid command
+	PROG_752 ProgCode = 752 // 👈 invalid buffer address
+	PROG_753 ProgCode = 753 // 👈 invalid or unsupported parameter
+	PROG_754 ProgCode = 754 // 👈 missing parameter
+)
+
+// 🟦 Error implementation
+
+func (p *ProgCheck) Error() string {
+	// 👇 an unknown command has no name
+	in := utils.Ternary(p.Cmd.String() != "", " in "+p.Cmd.String(), "")
+	return fmt.Sprintf("core: %s%s: %s at offset %d", p.Message(), in, p.Msg, p.At)
+}
+
+// 🟦 Public functions
+
+// 👇 as shown in the OIA after the X
+func (p *ProgCheck) Message() string {
+	return fmt.Sprintf("PROG %d", p.Code)
+}
+
+// 🟦 Helpers
+
+// 👇 look before we touch the buffer, so rejection is all or nothing
+func (c *Consumer) check(out *datastream.Outbound, err error) *ProgCheck {
+	var corrupt *datastream.Error
+	if errors.As(err, &corrupt) {
+		return &ProgCheck{At: corrupt.At, Cmd: out.Cmd, Code: utils.Ternary(corrupt.Truncated, PROG_754, PROG_753), Msg: corrupt.Msg}
+	}
+	switch out.Cmd {
+
+	case types.EAU, types.RB, types.RM, types.RMA:
+		return nil
+
+	case types.EW, types.EWA, types.W:
+		return c.checkOrders(out)
+
+	case types.WSF:
+		return c.checkSFlds(out)
+
+	}
+	return &ProgCheck{Cmd: out.Cmd, Code: PROG_750, Msg: fmt.Sprintf("command %#02x unknown", byte(out.Cmd))}
+}
+
+func (c *Consumer) checkOrders(out *datastream.Outbound) *ProgCheck {
+	for _, order := range out.Orders {
+		switch order.Order {
+
+		case types.EUA, types.RA, types.SBA:
+			if order.Addr >= c.emu.Buf.Len() {
+				row, col := c.emu.Cfg.Addr2RC(order.Addr)
+				return &ProgCheck{At: order.At, Cmd: out.Cmd, Code: PROG_752, Msg: fmt.Sprintf("%s addr %d/%d out of range", order.Order, row, col)}
+			}
+
+		// 👇 a character set we have no table for can't be drawn
+		case types.MF, types.SA, types.SFE:
+			for _, pair := range order.Pairs {
+				if _, ok := conv.CPs[types.LCID(pair.Value)]; pair.Type == types.CHARSET && !ok {
+					return &ProgCheck{At: order.At, Cmd: out.Cmd, Code: PROG_753, Msg: fmt.Sprintf("%s LCID %#02x not supported", order.Order, pair.Value)}
+				}
+			}
+
+		// TODO 🔥 PT not handled
+		case types.PT:
+			return &ProgCheck{At: order.At, Cmd: out.Cmd, Code: PROG_753, Msg: "PT not supported"}
+
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) checkSFlds(out *datastream.Outbound) *ProgCheck {
+	for _, sfld := range out.SFlds {
+		switch sfld.ID {
+
+		case types.READ_PARTITION, types.SET_REPLY_MODE:
+			continue
+
+		}
+		return &ProgCheck{At: sfld.At, Cmd: out.Cmd, Code: PROG_753, Msg: fmt.Sprintf("SFld %#02x not supported", byte(sfld.ID))}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"emulator/datastream"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgCheck(t *testing.T) {
+	good := datastream.NewBuilder(12, 40).
+		Command(types.EW).WCC(types.WCC{Unlock: true}).
+		SBA(2, 1).Text("Hello").
+		MustBytes()
+	for _, tc := range []struct {
+		name  string
+		chars []byte
+		err   string
+	}{
+		{"empty record", []byte{}, "core: PROG 754: command truncated at offset 0"},
+		{"unknown command", []byte{0x42, 0x00}, "core: PROG 750: command 0x42 unknown at offset 0"},
+		{"address off the screen", []byte{byte(types.W), 0x00, byte(types.SBA), 0x7f, 0x7f}, "core: PROG 752 in W: SBA addr 103/16 out of range at offset 2"},
+		{"truncated order", []byte{byte(types.W), 0x00, byte(types.SBA)}, "core: PROG 754 in W: SBA address truncated at offset 3"},
+		{"unsupported order", []byte{byte(types.W), 0x00, byte(types.PT)}, "core: PROG 753 in W: PT not supported at offset 2"},
+		{"unknown LCID", []byte{byte(types.W), 0x00, byte(types.SA), byte(types.CHARSET), 0x42, 0xc1}, "core: PROG 753 in W: SA LCID 0x42 not supported at offset 2"},
+		{"unsupported SFld", []byte{byte(types.WSF), 0x00, 0x04, byte(types.QUERY_REPLY), 0x00}, "core: PROG 753 in WSF: SFld 0x81 not supported at offset 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			emu := MockEmulator(12, 40).Initialize()
+			panicked := false
+			emu.Bus.SubPanic(func(_ string) {
+				panicked = true
+			})
+			emu.Bus.PubOutbound(good)
+			before := emu.Cells.Copy(0, emu.Buf.Len()-1, false)
+			// 👇 the command is rejected whole, and the emulator lives on
+			emu.Bus.PubOutbound(tc.chars)
+			assert.EqualError(t, emu.Out.Err(), tc.err)
+			assert.Equal(t, before, emu.Cells.Copy(0, emu.Buf.Len()-1, false))
+			assert.False(t, panicked)
+			assert.True(t, emu.State.Status.Error)
+			assert.True(t, emu.State.Status.Locked)
+			assert.Equal(t, tc.err[6:14], emu.State.Status.Message)
+		})
+	}
+
+	t.Run("X PROG stays until RESET", func(t *testing.T) {
+		emu := MockEmulator(12, 40).Initialize()
+		emu.Bus.PubOutbound([]byte{byte(types.W), 0x00, byte(types.PT)})
+		// 👇 a good command is applied, but the keyboard stays inhibited
+		emu.Bus.PubOutbound(append([]byte{byte(types.W)}, good[1:]...))
+		assert.NoError(t, emu.Out.Err())
+		assert.Equal(t, "Hello", emu.Cells.Copy(emu.Cfg.RC2Addr(2, 1), emu.Cfg.RC2Addr(2, 5), false))
+		assert.True(t, emu.State.Status.Locked)
+		emu.Bus.PubFocus(true)
+		assert.Equal(t, "PROG 753", emu.State.Status.Message)
+		emu.Bus.PubKeystroke(types.Keystroke{Code: "KeyR", CTRL: true, Key: "r"})
+		assert.False(t, emu.State.Status.Error)
+		assert.False(t, emu.State.Status.Locked)
+		assert.Empty(t, emu.State.Status.Message)
+	})
+
+	t.Run("or until the host erases the screen", func(t *testing.T) {
+		emu := MockEmulator(12, 40).Initialize()
+		emu.Bus.PubOutbound([]byte{byte(types.W), 0x00, byte(types.PT)})
+		emu.Bus.PubOutbound(good)
+		assert.False(t, emu.State.Status.Error)
+		assert.False(t, emu.State.Status.Locked)
+	})
+}
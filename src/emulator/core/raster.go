@@ -0,0 +1,117 @@
+package core
+
+import (
+	"emulator/utils"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// 🟧 Rasterize each character once as an alpha mask, so that
+//    drawing it in any color is just a cheap blit
+
+type Rasterizer struct {
+	masks map[maskKey]*image.Alpha
+}
+
+type maskKey struct {
+	bold bool
+	r    rune
+}
+
+// 🟦 Constructor
+
+func NewRasterizer() *Rasterizer {
+	r := new(Rasterizer)
+	r.masks = make(map[maskKey]*image.Alpha)
+	return r
+}
+
+// 🟦 Public functions
+
+// 👇 the mask of a rune, sized to the box and positioned on its baseline
+func (r *Rasterizer) MaskFor(face font.Face, bold bool, char rune, box Box) *image.Alpha {
+	key := maskKey{bold: bold, r: char}
+	mask, ok := r.masks[key]
+	if !ok {
+		mask = image.NewAlpha(image.Rect(0, 0, int(box.W), int(box.H)))
+		// 🔥 blanks are common and have nothing to draw
+		if face != nil && char > ' ' {
+			d := font.Drawer{
+				Dst:  mask,
+				Src:  image.Opaque,
+				Face: face,
+				Dot:  fixed.Point26_6{Y: fixed.Int26_6((box.Baseline - box.Y) * 64)},
+			}
+			d.DrawString(string(char))
+		}
+		r.masks[key] = mask
+	}
+	return mask
+}
+
+// 👇 forget every mask, as when the font or cell size changes
+func (r *Rasterizer) Reset() {
+	r.masks = make(map[maskKey]*image.Alpha)
+}
+
+// 🟦 Helpers
+
+// 👇 paint a mask onto an image in a solid color
+func colorize(dst draw.Image, mask *image.Alpha, c color.Color) {
+	draw.DrawMask(dst, dst.Bounds(), image.NewUniform(c), image.Point{}, mask, image.Point{}, draw.Over)
+}
+
+// 👇 paint a solid rectangle, like a line or a cursor
+func fill(dst draw.Image, rect image.Rectangle, c color.Color) {
+	draw.Draw(dst, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// 👇 wash a rectangle with a translucent color, like a tint
+func blend(dst draw.Image, rect image.Rectangle, c color.Color) {
+	draw.DrawMask(dst, rect, image.NewUniform(c), image.Point{}, image.NewUniform(color.Alpha{A: 0x40}), image.Point{}, draw.Over)
+}
+
+// 🟧 The standard renderer, drawing cells from the glyph cache
+//    onto the RGBA image that the UI copies to its <canvas>
+
+type RasterRenderer struct {
+	oia *OIA
+
+	emu *Emulator // 👈 back pointer to all common components
+}
+
+// 🟦 Constructor
+
+func NewRasterRenderer(emu *Emulator) *RasterRenderer {
+	r := new(RasterRenderer)
+	r.emu = emu
+	// 👇 the OIA font is only needed if we draw the OIA
+	if r.emu.Cfg.OIA {
+		r.oia = NewOIA()
+	}
+	return r
+}
+
+// 🟦 Renderer implementation
+
+func (r *RasterRenderer) Clear() image.Rectangle {
+	rgba := r.emu.Cfg.RGBA
+	fill(rgba, rgba.Bounds(), utils.HexColor(r.emu.Cfg.BgColor))
+	return rgba.Bounds()
+}
+
+func (r *RasterRenderer) Draw(g Glyph, box Box) image.Rectangle {
+	img := r.emu.GC.ImageFor(g, box)
+	rect := image.Rect(int(box.X), int(box.Y), int(box.X+box.W), int(box.Y+box.H))
+	draw.Draw(r.emu.Cfg.RGBA, rect, img, image.Point{}, draw.Src)
+	return rect
+}
+
+// 🔥 anyone holding the old surface must look again at Cfg.RGBA
+func (r *RasterRenderer) Resize() {
+	r.emu.Cfg.RGBA = image.NewRGBA(CanvasRect(r.emu.Cfg))
+}
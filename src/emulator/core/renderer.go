@@ -0,0 +1,49 @@
+package core
+
+import (
+	"image"
+)
+
+// 🟧 Draw the screen, cell by cell, onto some surface
+
+// 👇 the screen decides what each cell should look like, as a Glyph,
+//    and the renderer decides how to draw it -- each call answers the
+//    region of the surface that changed, if any
+
+type Renderer interface {
+	// 👇 erase the whole surface to the background color
+	Clear() image.Rectangle
+	// 👇 draw a single cell
+	Draw(g Glyph, box Box) image.Rectangle
+	// 👇 draw the Operator Information Area, indexed by column
+	DrawOIA(glyphs map[uint]OIAGlyph, color string) image.Rectangle
+	// 👇 the cell size has changed, so must the surface
+	Resize()
+}
+
+// 🟧 A renderer that draws nothing, for a headless emulator
+//    that needs neither fonts nor an image buffer
+
+type NoopRenderer struct{}
+
+// 🟦 Constructor
+
+func NewNoopRenderer() *NoopRenderer {
+	return new(NoopRenderer)
+}
+
+// 🟦 Renderer implementation
+
+func (r *NoopRenderer) Clear() image.Rectangle {
+	return image.Rectangle{}
+}
+
+func (r *NoopRenderer) Draw(_ Glyph, _ Box) image.Rectangle {
+	return image.Rectangle{}
+}
+
+func (r *NoopRenderer) DrawOIA(_ map[uint]OIAGlyph, _ string) image.Rectangle {
+	return image.Rectangle{}
+}
+
+func (r *NoopRenderer) Resize() {}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"emulator/types"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 a renderer that only remembers what it was asked to draw
+type spyRenderer struct {
+	NoopRenderer
+	drawn map[Box]Glyph
+}
+
+func (r *spyRenderer) Draw(g Glyph, box Box) image.Rectangle {
+	r.drawn[box] = g
+	return image.Rectangle{}
+}
+
+func TestNoopRenderer(t *testing.T) {
+	// 👇 no fonts, no image buffer
+	cfg := *MockEmulator(12, 40).Cfg
+	cfg.BoldFace = nil
+	cfg.NormalFace = nil
+	cfg.RGBA = nil
+	emu := NewEmulator(NewBus(), &cfg).Initialize()
+	assert.IsType(t, &NoopRenderer{}, emu.Scr.renderer)
+	assert.NotPanics(t, func() {
+		emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+		emu.Bus.PubRender()
+	})
+	assert.Equal(t, "Test screen", emu.Cells.Copy(emu.Cfg.RC2Addr(1, 11), emu.Cfg.RC2Addr(1, 21), false))
+	assert.Empty(t, emu.Scr.Damage())
+	assert.Zero(t, emu.GC.Len(), "nothing to warm without fonts")
+}
+
+func TestUseRenderer(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	spy := &spyRenderer{drawn: make(map[Box]Glyph)}
+	emu.Scr.UseRenderer(spy)
+	// 👇 the screen is redrawn on the new renderer, cell by cell
+	g := spy.drawn[NewBox(1, 11, emu.Cfg)]
+	assert.Equal(t, byte(0xe3), g.Char, "EBCDIC T")
+	assert.Equal(t, emu.Cfg.ColorOf(emu.Buf.MustPeek(emu.Cfg.RC2Addr(1, 11)).Attrs), g.Color)
+}
@@ -3,8 +3,8 @@ package core
 import (
 	"emulator/types"
 	"emulator/utils"
-
-	"github.com/fogleman/gg"
+	"fmt"
+	"image"
 )
 
 // 🟧 Model the screen onto which the buffer is rendered
@@ -14,6 +14,12 @@ type Screen struct {
 	clean  bool
 	cps    []Box
 	glyphs []Glyph
+	// 👇 what actually draws the cells, if anything
+	renderer Renderer
+	// 👇 where the cursor was when it was last drawn
+	cursorAt uint
+	// 👇 regions of the surface drawn since the last call to Damage()
+	damaged []image.Rectangle
 
 	emu *Emulator // 👈 back pointer to all common components
 }
@@ -23,6 +29,12 @@ type Screen struct {
 func NewScreen(emu *Emulator) *Screen {
 	s := new(Screen)
 	s.emu = emu
+	// 👇 without an image buffer, there's nothing to draw on
+	if s.emu.Cfg.RGBA == nil {
+		s.renderer = NewNoopRenderer()
+	} else {
+		s.renderer = NewRasterRenderer(emu)
+	}
 	// 👇 subscriptions
 	s.emu.Bus.SubInitialize(s.initialize)
 	s.emu.Bus.SubRender(s.render)
@@ -31,7 +43,12 @@ func NewScreen(emu *Emulator) *Screen {
 	s.emu.Bus.SubRenderDeltas(func(deltas *utils.Stack[uint]) {
 		s.renderDeltas(deltas, false, false)
 	})
+	s.emu.Bus.SubProfile(s.profile)
 	s.emu.Bus.SubReset(s.reset)
+	s.emu.Bus.SubResize(s.resize)
+	if s.emu.Cfg.OIA {
+		s.emu.Bus.SubStatus(s.renderOIA)
+	}
 	return s
 }
 
@@ -49,12 +66,100 @@ func (s *Screen) initialize() {
 	s.glyphs = make([]Glyph, s.emu.Cfg.Cols*s.emu.Cfg.Rows)
 }
 
+// 👇 switch colors without recreating the emulator
+func (s *Screen) profile(p types.Profile) {
+	s.emu.Cfg.ApplyProfile(p)
+	s.emu.GC.Warm()
+	s.reset()
+	s.renderAll()
+	if s.emu.Cfg.OIA {
+		s.renderOIA(s.emu.State.Status)
+	}
+}
+
+// 👇 zoom without recreating the emulator, so the session survives
+func (s *Screen) resize(geo types.Geometry) {
+	cfg := s.emu.Cfg
+	cfg.DPI = utils.Ternary(geo.DPI > 0, geo.DPI, cfg.DPI)
+	cfg.Family = utils.Ternary(geo.Family.Name != "", geo.Family, cfg.Family)
+	cfg.FontSize = utils.Ternary(geo.FontSize > 0, geo.FontSize, cfg.FontSize)
+	cfg.PaddedHeight = utils.Ternary(geo.PaddedHeight > 0, geo.PaddedHeight, cfg.PaddedHeight)
+	cfg.PaddedWidth = utils.Ternary(geo.PaddedWidth > 0, geo.PaddedWidth, cfg.PaddedWidth)
+	// 🔥 the mediator has already vetted any user-supplied font
+	normalFace, boldFace, fontWidth, fontHeight, _ := NewFaces(cfg.Family, cfg.FontSize, cfg.DPI)
+	cfg.BoldFace = &boldFace
+	cfg.FontHeight = fontHeight
+	cfg.FontWidth = fontWidth
+	cfg.NormalFace = &normalFace
+	s.renderer.Resize()
+	// 👇 now redraw everything from the buffer
+	s.emu.GC.Warm()
+	s.initialize()
+	s.reset()
+	s.renderAll()
+	if cfg.OIA {
+		s.renderOIA(s.emu.State.Status)
+	}
+}
+
 func (s *Screen) reset() {
-	dc := gg.NewContextForRGBA(s.emu.Cfg.RGBA)
-	dc.SetHexColor(s.emu.Cfg.BgColor)
-	dc.Clear()
 	s.glyphs = make([]Glyph, s.emu.Cfg.Cols*s.emu.Cfg.Rows)
 	s.clean = true
+	// 👇 everything must be redrawn
+	s.damaged = nil
+	s.damage(s.renderer.Clear())
+}
+
+// 🟦 Public functions
+
+// 👇 regions drawn since last asked, so only they need be copied
+func (s *Screen) Damage() []image.Rectangle {
+	damaged := s.damaged
+	s.damaged = nil
+	return damaged
+}
+
+// 👇 swap how the screen is drawn, say to run headless
+func (s *Screen) UseRenderer(r Renderer) {
+	s.renderer = r
+	if len(s.cps) > 0 {
+		s.reset()
+		s.renderAll()
+	}
+}
+
+// 👇 show or hide the debug overlay, optionally revealing non-display data
+func (s *Screen) ShowOverlay(overlay, reveal bool) {
+	s.emu.Cfg.Overlay = overlay
+	s.emu.Cfg.RevealHidden = reveal
+	if len(s.cps) > 0 {
+		s.renderAll()
+	}
+}
+
+// 👇 restyle the cursor and ruler, which only a full render shows
+func (s *Screen) ShowCursor(style types.CursorStyle, steady, ruler bool) {
+	s.emu.Cfg.CursorStyle = style
+	s.emu.Cfg.SteadyCursor = steady
+	s.emu.Cfg.Ruler = ruler
+	if len(s.cps) > 0 {
+		s.renderAll()
+	}
+}
+
+// 👇 translate canvas pixel coordinates into a buffer address
+func (s *Screen) AddrAt(x, y float64) (uint, bool) {
+	if x < 0 || y < 0 || len(s.cps) == 0 {
+		return 0, false
+	}
+	// 🔥 every box is the same size, so the first will do
+	box := s.cps[0]
+	row := uint(y/box.H) + 1
+	col := uint(x/box.W) + 1
+	if row > s.emu.Cfg.Rows || col > s.emu.Cfg.Cols {
+		return 0, false
+	}
+	return s.emu.Cfg.RC2Addr(row, col), true
 }
 
 // 🟦 Rendering functions
@@ -69,35 +174,67 @@ func (s *Screen) blink(counter int) {
 			blinkers.Push(addr)
 		}
 	}
-	// 👇 include the cursor if we have the focus
-	if !s.emu.State.Status.Locked {
+	// 👇 include the cursor if we have the focus and it blinks
+	if !s.emu.State.Status.Locked && !s.emu.Cfg.SteadyCursor {
 		blinkers.Push(s.emu.State.Status.CursorAt)
 	}
 	// 👇 now we can render
 	s.renderDeltas(blinkers, true, blinkOn)
 }
 
+// 👇 only render the cells the buffer says have changed
 func (s *Screen) render() {
-	dc := gg.NewContextForRGBA(s.emu.Cfg.RGBA)
+	touched, all := s.emu.Buf.Touched()
+	if all {
+		s.renderAll()
+		return
+	}
+	addrs := utils.NewStack[uint](len(touched) + 1)
+	for _, addr := range touched {
+		addrs.Push(addr)
+	}
+	// 👇 the cursor is always a candidate
+	addrs.Push(s.emu.State.Status.CursorAt)
+	s.renderDeltas(addrs, false, false)
+	s.emu.Buf.Untouch()
+}
+
+func (s *Screen) renderAll() {
 	// 👇 iterate over all cells
 	for addr := uint(0); addr < s.emu.Buf.Len(); addr++ {
-		s.renderImpl(dc, addr, false, false)
+		s.renderImpl(addr, false, false)
 	}
+	s.cursorAt = s.emu.State.Status.CursorAt
 	s.clean = false
+	s.emu.Buf.Untouch()
 }
 
 func (s *Screen) renderDeltas(addrs *utils.Stack[uint], doBlink bool, blinkOn bool) {
-	dc := gg.NewContextForRGBA(s.emu.Cfg.RGBA)
+	// 👇 if the cursor has moved, erase it and any ruler with it
+	if s.cursorAt != s.emu.State.Status.CursorAt {
+		addrs.Push(s.cursorAt)
+		if s.emu.Cfg.Ruler {
+			s.rulerDeltas(s.cursorAt, addrs)
+			s.rulerDeltas(s.emu.State.Status.CursorAt, addrs)
+		}
+		s.cursorAt = s.emu.State.Status.CursorAt
+	}
 	// 👇 iterate over all requested cells
 	for !addrs.Empty() {
 		if addr, ok := addrs.Pop(); ok {
-			s.renderImpl(dc, addr, doBlink, blinkOn)
+			s.renderImpl(addr, doBlink, blinkOn)
+			// 🔥 typing sets the MDT, which the overlay shows on the SF
+			if s.emu.Cfg.Overlay {
+				if fldAddr, ok := s.emu.Buf.MustPeek(addr).GetFldAddr(); ok && fldAddr != addr {
+					s.renderImpl(fldAddr, doBlink, blinkOn)
+				}
+			}
 		}
 	}
 	s.clean = false
 }
 
-func (s *Screen) renderImpl(dc *gg.Context, addr uint, doBlink bool, blinkOn bool) {
+func (s *Screen) renderImpl(addr uint, doBlink bool, blinkOn bool) {
 	// 👇 gather related data
 	box := s.cps[addr]
 	cell := s.emu.Buf.MustPeek(addr)
@@ -110,20 +247,31 @@ func (s *Screen) renderImpl(dc *gg.Context, addr uint, doBlink bool, blinkOn boo
 	}
 	reverse := a.Reverse && outline == 0x00
 	underscore := a.Underscore && outline == 0x00 && !cell.IsFldStart()
+	// 👇 the cursor is hidden when it blinks off, unless it is steady
+	style := s.emu.Cfg.CursorStyle
+	cursor := addr == s.emu.State.Status.CursorAt && (!doBlink || blinkOn || s.emu.Cfg.SteadyCursor)
+	blink := doBlink && blinkOn && a.Blink && !cell.IsFldStart()
 	// 🔥 != is the Go idiom for XOR
-	reverse = utils.Ternary(doBlink, reverse != blinkOn, reverse != (addr == s.emu.State.Status.CursorAt))
-	invisible := cell.Char == 0x00 || cell.IsFldStart() || a.Hidden
+	reverse = reverse != (blink || (cursor && style == types.CURSOR_BLOCK))
+	overlay := s.overlayOf(cell)
+	invisible := cell.Char == 0x00 || cell.IsFldStart() || (a.Hidden && !overlay.Hidden)
 	char := utils.Ternary(invisible, ' ', cell.Char)
+	ruler := s.rulerOf(addr)
 	// 🔥 optimization: if the screen is clean and the char blank, skip
-	if !s.clean || char > ' ' || outline != 0x00 || reverse || underscore {
+	if !s.clean || char > ' ' || outline != 0x00 || reverse || underscore || cursor || ruler != (Ruler{}) || overlay != (Overlay{}) {
 		// 👇 the cache will find us the glyph itself
 		g := Glyph{
-			Char:       char,
-			Color:      color,
-			Highlight:  a.Highlight || a.Intensify,
-			Reverse:    reverse,
-			Underscore: underscore,
-			LCID:       a.LCID,
+			Char:            char,
+			Color:           color,
+			CursorBar:       cursor && style == types.CURSOR_BAR,
+			CursorBlock:     cursor && style == types.CURSOR_BLOCK,
+			CursorUnderline: cursor && style == types.CURSOR_UNDERLINE,
+			Highlight:       a.Highlight || a.Intensify,
+			Reverse:         reverse,
+			Ruler:           ruler,
+			Underscore:      underscore,
+			LCID:            a.LCID,
+			Overlay:         overlay,
 		}
 		// 👇 outline surrounds the entire field
 		if outline != 0b00000000 {
@@ -137,9 +285,122 @@ func (s *Screen) renderImpl(dc *gg.Context, addr uint, doBlink bool, blinkOn boo
 		}
 		// 👇 if the glyph is already at this address, no need to redraw it
 		if g != s.glyphs[addr] {
-			img := s.emu.GC.ImageFor(g, box)
-			dc.DrawImage(img, int(box.X), int(box.Y))
+			s.damage(s.renderer.Draw(g, box))
 			s.glyphs[addr] = g
 		}
 	}
 }
+
+// 🟦 Damage tracking functions
+
+// 🔥 too many little rectangles cost more to copy than one big one
+const maxDamaged = 64
+
+func (s *Screen) damage(rect image.Rectangle) {
+	if rect.Empty() {
+		return
+	}
+	if n := len(s.damaged); n > 0 {
+		last := s.damaged[n-1]
+		switch {
+
+		case rect.In(last):
+			return
+
+		// 👇 coalesce adjacent cells on the same row
+		case last.Min.Y == rect.Min.Y && last.Max.Y == rect.Max.Y &&
+			(last.Max.X == rect.Min.X || last.Min.X == rect.Max.X):
+			s.damaged[n-1] = last.Union(rect)
+			return
+
+		}
+	}
+	s.damaged = append(s.damaged, rect)
+	if len(s.damaged) > maxDamaged {
+		union := image.Rectangle{}
+		for _, damaged := range s.damaged {
+			union = union.Union(damaged)
+		}
+		s.damaged = []image.Rectangle{union}
+	}
+}
+
+// 🟦 Overlay functions
+
+// 👇 how the debug overlay marks a cell, if at all
+func (s *Screen) overlayOf(cell *Cell) Overlay {
+	if !s.emu.Cfg.Overlay {
+		return Overlay{}
+	}
+	start := cell.IsFldStart()
+	overlay := Overlay{
+		CharAttr: cell.Attrs.CharAttr && !start,
+		FldStart: start,
+		Hidden:   s.emu.Cfg.RevealHidden && cell.Attrs.Hidden && !start && cell.Char > 0x40,
+	}
+	// 👇 the field's attributes decide the tint, not the cell's
+	if sf, ok := cell.GetFldStart(); ok {
+		overlay.MDT = start && sf.Attrs.MDT
+		switch {
+
+		case sf.Attrs.Protected:
+			overlay.Tint = TINT_PROTECTED
+
+		case sf.Attrs.Numeric:
+			overlay.Tint = TINT_NUMERIC
+
+		default:
+			overlay.Tint = TINT_UNPROTECTED
+
+		}
+	}
+	return overlay
+}
+
+// 🟦 Ruler (crosshair) functions
+
+// 👇 the ruler crosses the cursor, with a readout at the end of its row
+func (s *Screen) rulerOf(addr uint) Ruler {
+	if !s.emu.Cfg.Ruler {
+		return Ruler{}
+	}
+	row, col := s.emu.Cfg.Addr2RC(addr)
+	crow, ccol := s.emu.Cfg.Addr2RC(s.emu.State.Status.CursorAt)
+	ruler := Ruler{Col: col == ccol, Row: row == crow}
+	if ruler.Row {
+		ruler.Readout = s.readoutAt(addr)
+	}
+	return ruler
+}
+
+func (s *Screen) readoutAt(addr uint) rune {
+	cursorAt := s.emu.State.Status.CursorAt
+	crow, ccol := s.emu.Cfg.Addr2RC(cursorAt)
+	readout := fmt.Sprintf("%03d/%03d", crow, ccol)
+	width := uint(len(readout))
+	if s.emu.Cfg.Cols <= width {
+		return 0
+	}
+	start := s.emu.Cfg.RC2Addr(crow, s.emu.Cfg.Cols-width+1)
+	// 🔥 never obscure the cursor or any data
+	if addr < start || addr >= start+width || cursorAt >= start {
+		return 0
+	}
+	for ix := start; ix < start+width; ix++ {
+		cell := s.emu.Buf.MustPeek(ix)
+		if !cell.IsFldStart() && !cell.Attrs.Hidden && cell.Char > 0x40 {
+			return 0
+		}
+	}
+	return rune(readout[addr-start])
+}
+
+func (s *Screen) rulerDeltas(cursorAt uint, addrs *utils.Stack[uint]) {
+	row, col := s.emu.Cfg.Addr2RC(cursorAt)
+	for ix := uint(1); ix <= s.emu.Cfg.Cols; ix++ {
+		addrs.Push(s.emu.Cfg.RC2Addr(row, ix))
+	}
+	for ix := uint(1); ix <= s.emu.Cfg.Rows; ix++ {
+		addrs.Push(s.emu.Cfg.RC2Addr(ix, col))
+	}
+}
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var screenImg = []string{
+	/*                 1         2         3         4 */
+	/*        1234567890123456789012345678901234567890 */
+	/* 01 */ "         ¶Test screen                   ",
+	/* 02 */ "                                        ",
+	/* 03 */ "¶What is your name ?■                  ¶",
+	/* 04 */ "                                        ",
+	/* 05 */ "¶Listing                        Page 1  ",
+	/* 06 */ "                                        ",
+	/* 07 */ "                                        ",
+	/* 08 */ "                                        ",
+	/* 09 */ "                                        ",
+	/* 10 */ "                                        ",
+	/* 11 */ "                                        ",
+	/* 12 */ "                                        ",
+}
+
+func TestScreenCursorStyles(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	cursorAt := emu.Cfg.RC2Addr(3, 25)
+	emu.Kbd.MoveCursor(emu.State.Status.CursorAt, cursorAt, utils.NewStack[uint](2))
+
+	t.Run("block cursor is reverse video", func(t *testing.T) {
+		emu.Bus.PubRender()
+		g := emu.Scr.glyphs[cursorAt]
+		assert.True(t, g.Reverse)
+		assert.False(t, g.CursorBar || g.CursorUnderline)
+	})
+
+	t.Run("underline and bar cursors", func(t *testing.T) {
+		emu.Cfg.CursorStyle = types.CURSOR_UNDERLINE
+		emu.Bus.PubRender()
+		g := emu.Scr.glyphs[cursorAt]
+		assert.False(t, g.Reverse)
+		assert.True(t, g.CursorUnderline)
+		emu.Cfg.CursorStyle = types.CURSOR_BAR
+		emu.Bus.PubRender()
+		assert.True(t, emu.Scr.glyphs[cursorAt].CursorBar)
+	})
+
+	t.Run("blinking cursor blinks off", func(t *testing.T) {
+		emu.Bus.PubTick(0)
+		assert.False(t, emu.Scr.glyphs[cursorAt].CursorBar)
+		emu.Bus.PubTick(1)
+		assert.True(t, emu.Scr.glyphs[cursorAt].CursorBar)
+	})
+
+	t.Run("steady cursor does not blink", func(t *testing.T) {
+		emu.Cfg.SteadyCursor = true
+		emu.Bus.PubTick(0)
+		assert.True(t, emu.Scr.glyphs[cursorAt].CursorBar)
+	})
+}
+
+func TestScreenRuler(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Cfg.Ruler = true
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	emu.Bus.PubRender()
+
+	t.Run("ruler follows the cursor", func(t *testing.T) {
+		deltas := utils.NewStack[uint](2)
+		emu.Kbd.MoveCursor(emu.State.Status.CursorAt, emu.Cfg.RC2Addr(3, 25), deltas)
+		emu.Bus.PubRenderDeltas(deltas)
+		assert.Equal(t, Ruler{Row: true}, emu.Scr.glyphs[emu.Cfg.RC2Addr(3, 2)].Ruler)
+		assert.Equal(t, Ruler{Col: true}, emu.Scr.glyphs[emu.Cfg.RC2Addr(10, 25)].Ruler)
+		assert.Equal(t, Ruler{Col: true, Row: true}, emu.Scr.glyphs[emu.Cfg.RC2Addr(3, 25)].Ruler)
+		// 👇 and the old ruler is gone
+		assert.Equal(t, Ruler{}, emu.Scr.glyphs[emu.Cfg.RC2Addr(1, 10)].Ruler)
+	})
+
+	t.Run("readout at end of cursor row", func(t *testing.T) {
+		readout := ""
+		for col := uint(34); col <= 40; col++ {
+			readout += string(emu.Scr.glyphs[emu.Cfg.RC2Addr(3, col)].Ruler.Readout)
+		}
+		assert.Equal(t, "003/025", readout)
+	})
+
+	t.Run("readout never obscures data", func(t *testing.T) {
+		deltas := utils.NewStack[uint](2)
+		emu.Kbd.MoveCursor(emu.State.Status.CursorAt, emu.Cfg.RC2Addr(5, 10), deltas)
+		emu.Bus.PubRenderDeltas(deltas)
+		for col := uint(34); col <= 40; col++ {
+			assert.Zero(t, emu.Scr.glyphs[emu.Cfg.RC2Addr(5, col)].Ruler.Readout)
+		}
+	})
+}
+
+func TestScreenProfile(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	emu.Bus.PubRender()
+	box := NewBox(12, 40, emu.Cfg)
+	x, y := int(box.X+box.W/2), int(box.Y+box.H/2)
+	assert.Equal(t, utils.HexColor("#202020"), emu.Cfg.RGBA.RGBAAt(x, y))
+
+	t.Run("switch profile at runtime", func(t *testing.T) {
+		p, _ := types.ProfileOf("light")
+		emu.Bus.PubProfile(p)
+		assert.Equal(t, p.BgColor, emu.Cfg.BgColor)
+		assert.Equal(t, utils.HexColor(p.BgColor), emu.Cfg.RGBA.RGBAAt(x, y))
+		// 👇 every cached glyph must be drawn on the new background
+		for g, elem := range emu.GC.cache {
+			img := elem.Value.(*cached).img
+			if !g.Reverse && !g.CursorBlock {
+				assert.Equal(t, utils.HexColor(p.BgColor), img.At(0, 0), g)
+			}
+		}
+	})
+}
+
+func TestScreenResize(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	emu.Bus.PubRender()
+	before := emu.Cfg.RGBA.Bounds()
+	cells := emu.Buf.Len()
+
+	t.Run("zoom in keeps the session", func(t *testing.T) {
+		emu.Bus.PubResize(types.Geometry{FontSize: 24})
+		assert.Equal(t, 24.0, emu.Cfg.FontSize)
+		assert.Greater(t, emu.Cfg.RGBA.Bounds().Dx(), before.Dx())
+		assert.Equal(t, CanvasRect(emu.Cfg), emu.Cfg.RGBA.Bounds())
+		// 👇 boxes are recomputed to match the new size
+		assert.Equal(t, NewBox(12, 40, emu.Cfg), emu.Scr.cps[cells-1])
+		// 👇 the buffer is untouched and redrawn
+		assert.Equal(t, cells, emu.Buf.Len())
+		assert.Equal(t, "Test screen", emu.Cells.Copy(emu.Cfg.RC2Addr(1, 11), emu.Cfg.RC2Addr(1, 21), false))
+		assert.NotEmpty(t, emu.GC.cache)
+		for g, elem := range emu.GC.cache {
+			img := elem.Value.(*cached).img
+			box := emu.Scr.cps[0]
+			assert.Equal(t, int(box.W), img.Bounds().Dx(), g)
+		}
+	})
+}
+
+func TestScreenDamage(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+
+	t.Run("erase write damages everything", func(t *testing.T) {
+		assert.Equal(t, emu.Cfg.RGBA.Bounds(), emu.Scr.Damage()[0])
+		assert.Empty(t, emu.Scr.Damage(), "damage is reset once asked")
+	})
+
+	t.Run("write damages only what changed", func(t *testing.T) {
+		// 👇 overwrite "Listing" with "Listing" then "LISTING"
+		for _, text := range []string{"Listing", "LISTING"} {
+			stream := []byte{byte(types.W), 0x00, byte(types.SBA)}
+			stream = append(stream, conv.Addr2Bytes(emu.Cfg.RC2Addr(5, 2))...)
+			stream = append(stream, conv.A2Es(text)...)
+			emu.Bus.PubOutbound(stream)
+		}
+		damaged := emu.Scr.Damage()
+		assert.Len(t, damaged, 1)
+		// 👇 only the letters that were lowercase are redrawn
+		from := NewBox(5, 3, emu.Cfg)
+		to := NewBox(5, 8, emu.Cfg)
+		assert.Equal(t, image.Rect(int(from.X), int(from.Y), int(to.X+to.W), int(to.Y+to.H)), damaged[0])
+	})
+}
+
+func TestScreenOverlay(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	// 👇 the cells of a field share its attributes
+	emu.Buf.MustPeek(emu.Cfg.RC2Addr(5, 1)).Attrs.Hidden = true
+	sf := emu.Cfg.RC2Addr(3, 21)
+	hidden := emu.Cfg.RC2Addr(5, 2)
+	assert.Equal(t, Overlay{}, emu.Scr.glyphs[sf].Overlay)
+
+	t.Run("field starts and tints", func(t *testing.T) {
+		emu.Scr.ShowOverlay(true, false)
+		assert.Equal(t, Overlay{FldStart: true, Tint: TINT_UNPROTECTED}, emu.Scr.glyphs[sf].Overlay)
+		assert.Equal(t, Overlay{Tint: TINT_PROTECTED}, emu.Scr.glyphs[emu.Cfg.RC2Addr(1, 11)].Overlay)
+		assert.Equal(t, byte(' '), emu.Scr.glyphs[hidden].Char)
+	})
+
+	t.Run("non-display data revealed", func(t *testing.T) {
+		emu.Scr.ShowOverlay(true, true)
+		assert.True(t, emu.Scr.glyphs[hidden].Overlay.Hidden)
+		assert.Equal(t, conv.A2E('L'), emu.Scr.glyphs[hidden].Char)
+	})
+
+	t.Run("typing marks the field modified", func(t *testing.T) {
+		emu.State.Patch(types.Patch{CursorAt: utils.UintPtr(sf + 1)})
+		emu.Bus.PubPaste("Joe")
+		assert.True(t, emu.Scr.glyphs[sf].Overlay.MDT)
+	})
+
+	t.Run("overlay off", func(t *testing.T) {
+		emu.Scr.ShowOverlay(false, false)
+		assert.Equal(t, Overlay{}, emu.Scr.glyphs[sf].Overlay)
+		assert.Equal(t, byte(' '), emu.Scr.glyphs[hidden].Char)
+	})
+}
+
+func TestScreenShowCursor(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, screenImg, MockAttrsMap{}))
+	cursorAt := emu.State.Status.CursorAt
+	assert.True(t, emu.Scr.glyphs[cursorAt].CursorBlock)
+	// 👇 the new style is drawn without waiting for the cursor to move
+	emu.Scr.ShowCursor(types.CURSOR_BAR, true, true)
+	assert.True(t, emu.Scr.glyphs[cursorAt].CursorBar)
+	assert.False(t, emu.Scr.glyphs[cursorAt].CursorBlock)
+	assert.True(t, emu.Scr.glyphs[emu.Buf.WrapAddr(int(cursorAt+emu.Cfg.Cols))].Ruler.Col)
+}
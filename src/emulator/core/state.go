@@ -19,6 +19,7 @@ func NewState(emu *Emulator) *State {
 	s := new(State)
 	s.emu = emu
 	// 👇 subscriptions
+	s.emu.Bus.SubClose(s.close)
 	s.emu.Bus.SubInitialize(s.initialize)
 	s.emu.Bus.SubInbound(s.lock)
 	s.emu.Bus.SubOutbound(s.unlock)
@@ -27,6 +28,15 @@ func NewState(emu *Emulator) *State {
 	return s
 }
 
+// 👇 the host has gone, so nothing can be sent until we reconnect
+func (s *State) close() {
+	s.Patch(types.Patch{
+		Connected: utils.BoolPtr(false),
+		Locked:    utils.BoolPtr(true),
+		Waiting:   utils.BoolPtr(false),
+	})
+}
+
 func (s *State) initialize() {
 	s.reset()
 }
@@ -45,9 +55,13 @@ func (s *State) lock(_ []byte, _ PubInboundHints) {
 }
 
 func (s *State) unlock(_ []byte) {
+	// 🔥 any outbound data means we are in session with the host,
+	//    but an error (eg: X PROG) stays locked until RESET, and a
+	//    half-written buffer stays locked until the debugger is done
 	s.Patch(types.Patch{
-		Locked:  utils.BoolPtr(false),
-		Waiting: utils.BoolPtr(false),
+		Connected: utils.BoolPtr(true),
+		Locked:    utils.BoolPtr(s.Status.Error || s.emu.Dbg.Paused()),
+		Waiting:   utils.BoolPtr(false),
 	})
 }
 
@@ -65,6 +79,9 @@ func (s *State) Patch(p types.Patch) {
 	if p.Alarm != nil {
 		s.Status.Alarm = *p.Alarm
 	}
+	if p.Connected != nil {
+		s.Status.Connected = *p.Connected
+	}
 	if p.CursorAt != nil {
 		s.Status.CursorAt = *p.CursorAt
 	}
@@ -90,3 +90,18 @@ func TestStateLock(t *testing.T) {
 		assert.True(t, unlocked)
 	})
 }
+
+func TestStateConnected(t *testing.T) {
+	emu := MockEmulator(12, 40).Initialize()
+	emu.Bus.PubOutbound(MockStream(types.EW, types.WCC{Unlock: true}, MockExampleImg, MockExampleAttrs))
+	t.Run("connected once the host writes", func(t *testing.T) {
+		assert.True(t, emu.State.Status.Connected)
+		assert.False(t, emu.State.Status.Locked)
+	})
+
+	emu.Bus.PubClose()
+	t.Run("disconnected and locked on close", func(t *testing.T) {
+		assert.False(t, emu.State.Status.Connected)
+		assert.True(t, emu.State.Status.Locked)
+	})
+}
@@ -10,30 +10,35 @@ func _() {
 	var x [1]struct{}
 	_ = x[attn-0]
 	_ = x[close-1]
-	_ = x[focus-2]
-	_ = x[keystroke-3]
-	_ = x[inbound-4]
-	_ = x[initialize-5]
-	_ = x[outbound-6]
-	_ = x[panic-7]
-	_ = x[probe-8]
-	_ = x[q-9]
-	_ = x[ql-10]
-	_ = x[rb-11]
-	_ = x[render-12]
-	_ = x[renderDeltas-13]
-	_ = x[reset-14]
-	_ = x[rm-15]
-	_ = x[rma-16]
-	_ = x[status-17]
-	_ = x[tick-18]
-	_ = x[trace-19]
-	_ = x[wcchar-20]
+	_ = x[cursor-2]
+	_ = x[focus-3]
+	_ = x[keystroke-4]
+	_ = x[inbound-5]
+	_ = x[initialize-6]
+	_ = x[outbound-7]
+	_ = x[panic-8]
+	_ = x[paste-9]
+	_ = x[pointer-10]
+	_ = x[probe-11]
+	_ = x[profile-12]
+	_ = x[q-13]
+	_ = x[ql-14]
+	_ = x[rb-15]
+	_ = x[render-16]
+	_ = x[renderDeltas-17]
+	_ = x[reset-18]
+	_ = x[resize-19]
+	_ = x[rm-20]
+	_ = x[rma-21]
+	_ = x[status-22]
+	_ = x[tick-23]
+	_ = x[trace-24]
+	_ = x[wcchar-25]
 }
 
-const _Topic_name = "attnclosefocuskeystrokeinboundinitializeoutboundpanicprobeqqlrbrenderrenderDeltasresetrmrmastatusticktracewcchar"
+const _Topic_name = "attnclosecursorfocuskeystrokeinboundinitializeoutboundpanicpastepointerprobeprofileqqlrbrenderrenderDeltasresetresizermrmastatusticktracewcchar"
 
-var _Topic_index = [...]uint8{0, 4, 9, 14, 23, 30, 40, 48, 53, 58, 59, 61, 63, 69, 81, 86, 88, 91, 97, 101, 106, 112}
+var _Topic_index = [...]uint8{0, 4, 9, 15, 20, 29, 36, 46, 54, 59, 64, 71, 76, 83, 84, 86, 88, 94, 106, 111, 117, 119, 122, 128, 132, 137, 143}
 
 func (i Topic) String() string {
 	idx := int(i) - 0
@@ -0,0 +1,283 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"fmt"
+)
+
+// 🟧 Build an outbound (3270 <- app) record, for host apps and tests
+
+// 👇 for example:
+//
+//    chars, err := datastream.NewBuilder(24, 80).
+//       Command(types.EW).WCC(types.WCC{Unlock: true}).
+//       SBA(1, 1).SF(&types.Attrs{Protected: true}).Text("Name:").
+//       SF(&types.Attrs{}).IC().
+//       Bytes()
+//
+//    🔥 the first mistake is remembered, and reported by Bytes()
+
+type Builder struct {
+	chars []byte
+	cmd   types.Command
+	cols  uint
+	err   error
+	rows  uint
+	wcc   bool
+}
+
+// 🟦 Constructor
+
+func NewBuilder(rows, cols uint) *Builder {
+	b := new(Builder)
+	b.chars = make([]byte, 0)
+	b.cols = cols
+	b.rows = rows
+	return b
+}
+
+// 🟦 Public functions
+
+func (b *Builder) Bytes() ([]byte, error) {
+	switch {
+
+	case b.err != nil:
+		return nil, b.err
+
+	case len(b.chars) == 0:
+		return nil, b.fail("command missing")
+
+	case b.isWrite() && !b.wcc:
+		return nil, b.fail("WCC missing")
+	}
+	return append([]byte(nil), b.chars...), nil
+}
+
+func (b *Builder) Command(cmd types.Command) *Builder {
+	if len(b.chars) > 0 {
+		b.fail("command %s follows %s", cmd, b.cmd)
+	} else {
+		b.cmd = cmd
+		b.put(byte(cmd))
+	}
+	return b
+}
+
+func (b *Builder) EUA(row, col uint) *Builder {
+	if addr, ok := b.order(types.EUA, row, col); ok {
+		b.put(byte(types.EUA))
+		b.put(conv.Addr2Bytes(addr)...)
+	}
+	return b
+}
+
+// 🔥 char is from the GE code page, not ASCII
+func (b *Builder) GE(char byte) *Builder {
+	if b.canOrder(types.GE) {
+		b.put(byte(types.GE), char)
+	}
+	return b
+}
+
+func (b *Builder) IC() *Builder {
+	if b.canOrder(types.IC) {
+		b.put(byte(types.IC))
+	}
+	return b
+}
+
+func (b *Builder) MF(attrs *types.Attrs) *Builder {
+	if b.canOrder(types.MF) {
+		b.put(byte(types.MF))
+		b.pairs(attrs)
+	}
+	return b
+}
+
+func (b *Builder) MustBytes() []byte {
+	chars, err := b.Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return chars
+}
+
+func (b *Builder) PT() *Builder {
+	if b.canOrder(types.PT) {
+		b.put(byte(types.PT))
+	}
+	return b
+}
+
+func (b *Builder) Query() *Builder {
+	return b.SFld(types.READ_PARTITION, 0xfF, byte(types.Q))
+}
+
+// 👇 no qcodes at all means query all of them
+func (b *Builder) QueryList(qcodes ...types.QCode) *Builder {
+	if len(qcodes) == 0 {
+		return b.SFld(types.READ_PARTITION, 0xfF, byte(types.QL), 0b10000000)
+	}
+	info := []byte{0xfF, byte(types.QL), 0b00000000}
+	for _, qcode := range qcodes {
+		info = append(info, byte(qcode))
+	}
+	return b.SFld(types.READ_PARTITION, info...)
+}
+
+// 🔥 char is ASCII, like Text()
+func (b *Builder) RA(row, col uint, char byte) *Builder {
+	if addr, ok := b.order(types.RA, row, col); ok {
+		b.put(byte(types.RA))
+		b.put(conv.Addr2Bytes(addr)...)
+		b.put(conv.A2E(char))
+	}
+	return b
+}
+
+// 👇 one SA order for each attribute pair
+func (b *Builder) SA(attrs *types.Attrs) *Builder {
+	if b.canOrder(types.SA) {
+		raw := attrs.Bytes()
+		for ix := 0; ix < len(raw); ix += 2 {
+			b.put(byte(types.SA), raw[ix], raw[ix+1])
+		}
+	}
+	return b
+}
+
+func (b *Builder) SBA(row, col uint) *Builder {
+	if addr, ok := b.order(types.SBA, row, col); ok {
+		b.put(byte(types.SBA))
+		b.put(conv.Addr2Bytes(addr)...)
+	}
+	return b
+}
+
+// 👇 the implicit partition is the only one we support
+func (b *Builder) SetReplyMode(mode types.Mode, typecodes ...types.Typecode) *Builder {
+	info := []byte{0x00, byte(mode)}
+	for _, typecode := range typecodes {
+		info = append(info, byte(typecode))
+	}
+	return b.SFld(types.SET_REPLY_MODE, info...)
+}
+
+func (b *Builder) SF(attrs *types.Attrs) *Builder {
+	if b.canOrder(types.SF) {
+		b.put(byte(types.SF), attrs.Bits())
+	}
+	return b
+}
+
+func (b *Builder) SFE(attrs *types.Attrs) *Builder {
+	if b.canOrder(types.SFE) {
+		b.put(byte(types.SFE))
+		b.pairs(attrs)
+	}
+	return b
+}
+
+// 👇 info includes any partition ID
+func (b *Builder) SFld(id types.SFID, info ...byte) *Builder {
+	switch {
+
+	case b.err != nil:
+
+	case b.cmd != types.WSF || len(b.chars) == 0:
+		b.fail("SFld %s outside WSF", id)
+
+	case len(info)+3 > 0xfFfF:
+		b.fail("SFld %s too long", id)
+
+	default:
+		length := len(info) + 3
+		b.put(byte(length>>8), byte(length))
+		b.put(byte(id))
+		b.put(info...)
+	}
+	return b
+}
+
+// 🔥 str is ASCII
+func (b *Builder) Text(str string) *Builder {
+	if b.canOrder(DATA) {
+		b.put([]byte(conv.A2Es(str))...)
+	}
+	return b
+}
+
+func (b *Builder) WCC(wcc types.WCC) *Builder {
+	switch {
+
+	case b.err != nil:
+
+	case !b.isWrite() || len(b.chars) != 1:
+		b.fail("WCC must follow EW, EWA or W")
+
+	default:
+		b.wcc = true
+		b.put(wcc.Bits())
+	}
+	return b
+}
+
+// 🟦 Helpers
+
+func (b *Builder) canOrder(order types.Order) bool {
+	what := utils.Ternary(order == DATA, "data", order.String())
+	switch {
+
+	case b.err != nil:
+		return false
+
+	case !b.isWrite() || len(b.chars) == 0:
+		b.fail("%s outside EW, EWA or W", what)
+		return false
+
+	case !b.wcc:
+		b.fail("%s before WCC", what)
+		return false
+	}
+	return true
+}
+
+func (b *Builder) fail(msg string, args ...any) error {
+	if b.err == nil {
+		b.err = &Error{At: len(b.chars), Msg: fmt.Sprintf(msg, args...)}
+	}
+	return b.err
+}
+
+func (b *Builder) isWrite() bool {
+	return b.cmd == types.EW || b.cmd == types.EWA || b.cmd == types.W
+}
+
+func (b *Builder) order(order types.Order, row, col uint) (uint, bool) {
+	if !b.canOrder(order) {
+		return 0, false
+	}
+	if row < 1 || row > b.rows || col < 1 || col > b.cols {
+		b.fail("%s row %d col %d outside %dx%d screen", order, row, col, b.rows, b.cols)
+		return 0, false
+	}
+	addr := (row-1)*b.cols + col - 1
+	// 🔥 we only support 12-bit addressing
+	if addr > 0xfFf {
+		b.fail("%s row %d col %d needs 14-bit addressing", order, row, col)
+		return 0, false
+	}
+	return addr, true
+}
+
+func (b *Builder) pairs(attrs *types.Attrs) {
+	raw := attrs.Bytes()
+	b.put(byte(len(raw) / 2))
+	b.put(raw...)
+}
+
+func (b *Builder) put(chars ...byte) {
+	b.chars = append(b.chars, chars...)
+}
@@ -0,0 +1,75 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	t.Run("a write round trips through the decoder", func(t *testing.T) {
+		chars, err := NewBuilder(24, 80).
+			Command(types.EW).WCC(types.WCC{Alarm: true, Unlock: true}).
+			SBA(2, 1).SF(&types.Attrs{Protected: true}).Text("Name:").
+			SFE(&types.Attrs{Color: types.RED, MDT: true}).IC().
+			SA(&types.Attrs{Reverse: true}).RA(2, 20, '_').
+			EUA(24, 80).GE(0xad).MF(&types.Attrs{Numeric: true}).PT().
+			Bytes()
+		assert.NoError(t, err)
+		out, err := DecodeOutbound(chars)
+		assert.NoError(t, err)
+		assert.Equal(t, types.WCC{Alarm: true, Unlock: true}, *out.WCC)
+		kinds := make([]types.Order, 0)
+		for _, order := range out.Orders {
+			kinds = append(kinds, order.Order)
+		}
+		assert.Equal(t, []types.Order{types.SBA, types.SF, DATA, types.SFE, types.IC, types.SA, types.RA, types.EUA, types.GE, types.MF, types.PT}, kinds)
+		assert.Equal(t, uint(80), out.Orders[0].Addr)
+		assert.Equal(t, (&types.Attrs{Protected: true}).Bits(), out.Orders[1].Attr)
+		assert.Equal(t, []byte(conv.A2Es("Name:")), out.Orders[2].Chars)
+		assert.Equal(t, Pairs{{types.BASIC, 0x01}, {types.COLOR, byte(types.RED)}}, out.Orders[3].Pairs)
+		assert.Equal(t, Order{At: 22, Order: types.RA, Addr: 99, Chars: []byte{conv.A2E('_')}}, out.Orders[6])
+		assert.Equal(t, uint(1919), out.Orders[7].Addr)
+	})
+
+	t.Run("structured fields round trip too", func(t *testing.T) {
+		chars := NewBuilder(24, 80).
+			Command(types.WSF).
+			Query().
+			QueryList(types.COLOR_SUPPORT, types.HIGHLIGHTING).
+			QueryList().
+			SetReplyMode(types.CHARACTER_MODE, types.COLOR).
+			MustBytes()
+		out, err := DecodeOutbound(chars)
+		assert.NoError(t, err)
+		assert.Len(t, out.SFlds, 4)
+		assert.Equal(t, SFld{At: 1, HasPID: true, ID: types.READ_PARTITION, Info: []byte{byte(types.Q)}, PID: 0xff}, out.SFlds[0])
+		assert.Equal(t, []byte{byte(types.QL), 0x00, byte(types.COLOR_SUPPORT), byte(types.HIGHLIGHTING)}, out.SFlds[1].Info)
+		assert.Equal(t, []byte{byte(types.QL), 0x80}, out.SFlds[2].Info)
+		assert.Equal(t, SFld{At: 20, HasPID: true, ID: types.SET_REPLY_MODE, Info: []byte{byte(types.CHARACTER_MODE), byte(types.COLOR)}, PID: 0x00}, out.SFlds[3])
+	})
+
+	t.Run("the first mistake is reported", func(t *testing.T) {
+		tests := []struct {
+			b   *Builder
+			err string
+		}{
+			{NewBuilder(24, 80), "datastream: command missing at offset 0"},
+			{NewBuilder(24, 80).Command(types.W), "datastream: WCC missing at offset 1"},
+			{NewBuilder(24, 80).Command(types.W).IC(), "datastream: IC before WCC at offset 1"},
+			{NewBuilder(24, 80).Command(types.W).Command(types.EW), "datastream: command EW follows W at offset 1"},
+			{NewBuilder(24, 80).Command(types.WSF).WCC(types.WCC{}), "datastream: WCC must follow EW, EWA or W at offset 1"},
+			{NewBuilder(24, 80).Command(types.WSF).Text("x"), "datastream: data outside EW, EWA or W at offset 1"},
+			{NewBuilder(24, 80).Command(types.EW).WCC(types.WCC{}).Query(), "datastream: SFld READ_PARTITION outside WSF at offset 2"},
+			{NewBuilder(24, 80).Command(types.EW).WCC(types.WCC{}).SBA(25, 1).SBA(0, 0), "datastream: SBA row 25 col 1 outside 24x80 screen at offset 2"},
+			{NewBuilder(100, 80).Command(types.EW).WCC(types.WCC{}).EUA(100, 1), "datastream: EUA row 100 col 1 needs 14-bit addressing at offset 2"},
+		}
+		for _, test := range tests {
+			_, err := test.b.Bytes()
+			assert.EqualError(t, err, test.err)
+		}
+		assert.Panics(t, func() { NewBuilder(24, 80).MustBytes() })
+	})
+}
@@ -0,0 +1,48 @@
+package datastream
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/types"
+)
+
+// 🟧 Inbound (3270 -> app) record, decoded
+
+type Inbound struct {
+	AID    types.AID
+	Cursor uint    // 👈 unless a short read
+	Orders []Order // 👈 RB, RM and RMA
+	SFlds  []SFld  // 👈 WSF, eg: QUERY_REPLY
+}
+
+// 🟦 Public functions
+
+// 🔥 on error, what was decoded up to the offset in error is still returned
+func DecodeInbound(chars []byte) (*Inbound, error) {
+	// 👇 the record may still be framed by LT
+	chars, _ = bytes.CutSuffix(chars, types.LT)
+	s := newStream(chars)
+	in := new(Inbound)
+	char, err := s.next("AID")
+	if err != nil {
+		return in, err
+	}
+	in.AID = types.AID(char)
+	switch {
+
+	case in.AID == types.INBOUND:
+		in.SFlds, err = decodeSFlds(s)
+
+	// 👇 short read
+	case !s.hasNext():
+
+	// 👇 RB, RM or RMA
+	default:
+		var raw []byte
+		if raw, err = s.nextSlice(2, "cursor address"); err == nil {
+			in.Cursor = conv.Bytes2Addr(raw)
+			in.Orders, err = decodeOrders(s, inboundOrders)
+		}
+	}
+	return in, err
+}
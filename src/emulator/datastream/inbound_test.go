@@ -0,0 +1,43 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeInbound(t *testing.T) {
+	t.Run("RM has a cursor and SBA orders", func(t *testing.T) {
+		raw := []any{types.ENTER, conv.Addr2Bytes(85), types.SBA, conv.Addr2Bytes(81), "abc", types.LT}
+		in, err := DecodeInbound(utils.Flatten2Bytes(raw, conv.A2Es))
+		assert.NoError(t, err)
+		assert.Equal(t, types.ENTER, in.AID)
+		assert.Equal(t, uint(85), in.Cursor)
+		assert.Len(t, in.Orders, 2)
+		assert.Equal(t, uint(81), in.Orders[0].Addr)
+		assert.Equal(t, []byte(conv.A2Es("abc")), in.Orders[1].Chars)
+	})
+
+	t.Run("a short read is just the AID", func(t *testing.T) {
+		in, err := DecodeInbound([]byte{byte(types.CLEAR)})
+		assert.NoError(t, err)
+		assert.Equal(t, types.CLEAR, in.AID)
+		assert.Nil(t, in.Orders)
+	})
+
+	t.Run("a query reply has structured fields", func(t *testing.T) {
+		raw := []any{types.INBOUND, []byte{0x00, 0x05}, types.QUERY_REPLY, types.COLOR_SUPPORT, 0x00}
+		in, err := DecodeInbound(utils.Flatten2Bytes(raw, nil))
+		assert.NoError(t, err)
+		assert.Len(t, in.SFlds, 1)
+		assert.Equal(t, []byte{byte(types.COLOR_SUPPORT), 0x00}, in.SFlds[0].Info)
+	})
+
+	t.Run("a truncated cursor is an error", func(t *testing.T) {
+		_, err := DecodeInbound([]byte{byte(types.ENTER), 0x40})
+		assert.EqualError(t, err, "datastream: cursor address truncated at offset 1")
+	})
+}
@@ -0,0 +1,177 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"fmt"
+)
+
+// 🟧 A single order, or a run of data between orders
+
+type Order struct {
+	At    int         // 👈 offset of the order in the record
+	Order types.Order // 👈 DATA for a run of data
+	Addr  uint        // 👈 EUA, RA and SBA
+	Attr  byte        // 👈 SF
+	Chars []byte      // 👈 DATA, or the char written by GE and RA
+	GE    bool        // 👈 RA repeats a GE char
+	Pairs Pairs       // 👈 MF, SA and SFE
+}
+
+// 🟧 Attribute type/value pair
+
+type Pair struct {
+	Type  types.Typecode
+	Value byte
+}
+
+type Pairs []Pair
+
+// 🟦 Lookup tables
+
+// 👇 pseudo-order for a run of data, as 0x00 is not a real order
+const DATA types.Order = 0x00
+
+// 👇 only these orders may appear in RB and RM replies
+var inboundOrders = map[types.Order]bool{
+	types.SA:  true,
+	types.SBA: true,
+	types.SF:  true,
+	types.SFE: true,
+}
+
+var outboundOrders = map[types.Order]bool{
+	types.EUA: true,
+	types.GE:  true,
+	types.IC:  true,
+	types.MF:  true,
+	types.PT:  true,
+	types.RA:  true,
+	types.SA:  true,
+	types.SBA: true,
+	types.SF:  true,
+	types.SFE: true,
+}
+
+// 🟦 Public functions
+
+func (p Pairs) Bytes() []byte {
+	chars := make([]byte, 0, len(p)*2)
+	for _, pair := range p {
+		chars = append(chars, byte(pair.Type), pair.Value)
+	}
+	return chars
+}
+
+// 🟦 Stringer implementation
+
+func (o Order) String() string {
+	switch o.Order {
+
+	case DATA:
+		return fmt.Sprintf("DATA % #x", o.Chars)
+
+	case types.EUA, types.SBA:
+		return fmt.Sprintf("%s %d", o.Order, o.Addr)
+
+	case types.GE:
+		return fmt.Sprintf("GE %#02x", o.Chars[0])
+
+	case types.MF, types.SA, types.SFE:
+		return fmt.Sprintf("%s % #x", o.Order, o.Pairs.Bytes())
+
+	case types.RA:
+		return fmt.Sprintf("RA %d %s%#02x", o.Addr, utils.Ternary(o.GE, "GE ", ""), o.Chars[0])
+
+	case types.SF:
+		return fmt.Sprintf("SF %#02x", o.Attr)
+
+	default:
+		return o.Order.String()
+	}
+}
+
+// 🟦 Helpers
+
+func decodeOrders(s *stream, known map[types.Order]bool) ([]Order, error) {
+	orders := make([]Order, 0)
+	for s.hasNext() {
+		at := s.ix
+		char, _ := s.next("order")
+		// 👇 if it isn't an order, it's data, which we gather into a run
+		if !known[types.Order(char)] {
+			for s.hasNext() && !known[types.Order(s.chars[s.ix])] {
+				s.ix++
+			}
+			orders = append(orders, Order{At: at, Order: DATA, Chars: s.chars[at:s.ix]})
+			continue
+		}
+		order, err := decodeOrder(s, at, types.Order(char))
+		if err != nil {
+			return orders, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func decodeOrder(s *stream, at int, order types.Order) (Order, error) {
+	o := Order{At: at, Order: order}
+	var err error
+	switch order {
+
+	case types.EUA, types.SBA:
+		o.Addr, err = decodeAddr(s, order)
+
+	case types.GE:
+		o.Chars, err = s.nextSlice(1, "GE char")
+
+	case types.MF, types.SFE:
+		o.Pairs, err = decodePairs(s, order)
+
+	case types.RA:
+		if o.Addr, err = decodeAddr(s, order); err == nil {
+			o.Chars, err = s.nextSlice(1, "RA char")
+			// 👇 the repeated char may itself be a GE
+			if err == nil && types.Order(o.Chars[0]) == types.GE {
+				o.GE = true
+				o.Chars, err = s.nextSlice(1, "RA GE char")
+			}
+		}
+
+	case types.SA:
+		var pair []byte
+		if pair, err = s.nextSlice(2, "SA pair"); err == nil {
+			o.Pairs = Pairs{{Type: types.Typecode(pair[0]), Value: pair[1]}}
+		}
+
+	case types.SF:
+		o.Attr, err = s.next("SF attribute")
+	}
+	return o, err
+}
+
+func decodeAddr(s *stream, order types.Order) (uint, error) {
+	raw, err := s.nextSlice(2, fmt.Sprintf("%s address", order))
+	if err != nil {
+		return 0, err
+	}
+	return conv.Bytes2Addr(raw), nil
+}
+
+func decodePairs(s *stream, order types.Order) (Pairs, error) {
+	count, err := s.next(fmt.Sprintf("%s count", order))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := s.nextSlice(int(count)*2, fmt.Sprintf("%s pairs", order))
+	if err != nil {
+		return nil, err
+	}
+	pairs := make(Pairs, 0, count)
+	for ix := 0; ix < len(raw); ix += 2 {
+		pairs = append(pairs, Pair{Type: types.Typecode(raw[ix]), Value: raw[ix+1]})
+	}
+	return pairs, nil
+}
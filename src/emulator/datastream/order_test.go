@@ -0,0 +1,58 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeOrders(t *testing.T) {
+	raw := []any{
+		types.SBA, conv.Addr2Bytes(81),
+		types.SFE, 0x02, types.BASIC, 0x20, types.COLOR, types.RED,
+		"Hi",
+		types.SA, types.HIGHLIGHT, types.REVERSE,
+		types.RA, conv.Addr2Bytes(100), types.GE, 0xad,
+		types.IC,
+		types.SF, 0x60,
+		types.EUA, conv.Addr2Bytes(0),
+	}
+	s := newStream(utils.Flatten2Bytes(raw, conv.A2Es))
+	orders, err := decodeOrders(s, outboundOrders)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 8)
+
+	assert.Equal(t, Order{At: 0, Order: types.SBA, Addr: 81}, orders[0])
+	assert.Equal(t, Pairs{{types.BASIC, 0x20}, {types.COLOR, byte(types.RED)}}, orders[1].Pairs)
+	assert.Equal(t, Order{At: 9, Order: DATA, Chars: []byte(conv.A2Es("Hi"))}, orders[2])
+	assert.Equal(t, Pairs{{types.HIGHLIGHT, byte(types.REVERSE)}}, orders[3].Pairs)
+	assert.Equal(t, Order{At: 14, Order: types.RA, Addr: 100, Chars: []byte{0xad}, GE: true}, orders[4])
+	assert.Equal(t, types.IC, orders[5].Order)
+	assert.Equal(t, byte(0x60), orders[6].Attr)
+	assert.Equal(t, uint(0), orders[7].Addr)
+
+	t.Run("inbound streams have fewer orders", func(t *testing.T) {
+		s := newStream([]byte{byte(types.SF), 0x60, byte(types.IC), 0xc1})
+		orders, err := decodeOrders(s, inboundOrders)
+		assert.NoError(t, err)
+		assert.Len(t, orders, 2)
+		assert.Equal(t, []byte{byte(types.IC), 0xc1}, orders[1].Chars)
+	})
+
+	t.Run("truncated orders are errors", func(t *testing.T) {
+		s := newStream([]byte{byte(types.SF), 0x60, byte(types.SFE), 0x02, byte(types.BASIC), 0x20})
+		orders, err := decodeOrders(s, outboundOrders)
+		assert.EqualError(t, err, "datastream: SFE pairs truncated at offset 4")
+		assert.Len(t, orders, 1, "orders before the error are kept")
+	})
+}
+
+func TestOrderString(t *testing.T) {
+	assert.Equal(t, "SBA 81", Order{Order: types.SBA, Addr: 81}.String())
+	assert.Equal(t, "RA 100 GE 0xad", Order{Order: types.RA, Addr: 100, Chars: []byte{0xad}, GE: true}.String())
+	assert.Equal(t, "DATA 0xc8 0x89", Order{Order: DATA, Chars: []byte{0xc8, 0x89}}.String())
+	assert.Equal(t, "IC", Order{Order: types.IC}.String())
+}
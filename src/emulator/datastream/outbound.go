@@ -0,0 +1,42 @@
+package datastream
+
+import (
+	"emulator/types"
+)
+
+// 🟧 Outbound (3270 <- app) record, decoded
+
+type Outbound struct {
+	Cmd    types.Command
+	Orders []Order    // 👈 EW, EWA and W
+	SFlds  []SFld     // 👈 WSF
+	WCC    *types.WCC // 👈 nil if the command has none
+}
+
+// 🟦 Public functions
+
+// 🔥 on error, what was decoded up to the offset in error is still returned
+func DecodeOutbound(chars []byte) (*Outbound, error) {
+	s := newStream(chars)
+	out := new(Outbound)
+	char, err := s.next("command")
+	if err != nil {
+		return out, err
+	}
+	out.Cmd = types.Command(char)
+	// 👇 dispatch on command
+	switch out.Cmd {
+
+	case types.EW, types.EWA, types.W:
+		if char, ok := s.peek(); ok {
+			s.ix++
+			wcc := types.NewWCC(char)
+			out.WCC = &wcc
+			out.Orders, err = decodeOrders(s, outboundOrders)
+		}
+
+	case types.WSF:
+		out.SFlds, err = decodeSFlds(s)
+	}
+	return out, err
+}
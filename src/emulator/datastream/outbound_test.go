@@ -0,0 +1,70 @@
+package datastream
+
+import (
+	"emulator/conv"
+	"emulator/types"
+	"emulator/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeOutbound(t *testing.T) {
+	t.Run("write commands have a WCC and orders", func(t *testing.T) {
+		raw := []any{types.EW, 0xc3, types.SBA, conv.Addr2Bytes(0), types.SF, 0x60, "Hello", types.IC}
+		out, err := DecodeOutbound(utils.Flatten2Bytes(raw, conv.A2Es))
+		assert.NoError(t, err)
+		assert.Equal(t, types.EW, out.Cmd)
+		assert.Equal(t, types.NewWCC(0xc3), *out.WCC)
+		assert.Len(t, out.Orders, 4)
+		assert.Empty(t, out.SFlds)
+	})
+
+	t.Run("a write without a WCC is empty", func(t *testing.T) {
+		out, err := DecodeOutbound([]byte{byte(types.EWA)})
+		assert.NoError(t, err)
+		assert.Nil(t, out.WCC)
+		assert.Empty(t, out.Orders)
+	})
+
+	t.Run("WSF has structured fields", func(t *testing.T) {
+		out, err := DecodeOutbound([]byte{byte(types.WSF), 0x00, 0x05, byte(types.READ_PARTITION), 0xff, byte(types.QL)})
+		assert.NoError(t, err)
+		assert.Len(t, out.SFlds, 1)
+		assert.Equal(t, []byte{byte(types.QL)}, out.SFlds[0].Info)
+	})
+
+	t.Run("other commands have no data", func(t *testing.T) {
+		out, err := DecodeOutbound([]byte{byte(types.RM)})
+		assert.NoError(t, err)
+		assert.Equal(t, types.RM, out.Cmd)
+	})
+
+	t.Run("corrupt records are decoded up to the error", func(t *testing.T) {
+		out, err := DecodeOutbound([]byte{byte(types.W), 0x00, byte(types.IC), byte(types.SBA), 0x40})
+		var corrupt *Error
+		assert.ErrorAs(t, err, &corrupt)
+		assert.Equal(t, 4, corrupt.At)
+		assert.Len(t, out.Orders, 1)
+	})
+
+	t.Run("so are empty ones", func(t *testing.T) {
+		_, err := DecodeOutbound([]byte{})
+		assert.EqualError(t, err, "datastream: command truncated at offset 0")
+	})
+}
+
+func FuzzDecodeOutbound(f *testing.F) {
+	f.Add([]byte{byte(types.W), 0xc3, byte(types.SBA), 0x40, 0x40, 0xc8, byte(types.RA), 0x40, 0x50, byte(types.GE), 0xad})
+	f.Add([]byte{byte(types.EW), 0x00, byte(types.SFE), 0x01, byte(types.BASIC), 0x20, byte(types.SA), 0x01, byte(types.COLOR), 0xf2})
+	f.Add([]byte{byte(types.WSF), 0x00, 0x05, byte(types.READ_PARTITION), 0xff, 0x02})
+	f.Fuzz(func(t *testing.T, chars []byte) {
+		out, err := DecodeOutbound(chars)
+		assert.NotNil(t, out)
+		// 👇 errors are always located in the record
+		var corrupt *Error
+		if err != nil && assert.ErrorAs(t, err, &corrupt) {
+			assert.LessOrEqual(t, corrupt.At, len(chars))
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package datastream
+
+import (
+	"emulator/types"
+	"fmt"
+)
+
+// 🟧 3270 Structured Field
+
+// 👁️ All page references to:
+// https://bitsavers.org/pdf/ibm/3270/GA23-0059-07_3270_Data_Stream_Programmers_Reference_199206.pdf
+
+type SFld struct {
+	At     int  // 👈 offset of the length in the record
+	HasPID bool // 👈 true if the field is addressed to a partition
+	ID     types.SFID
+	Info   []byte // 👈 everything after the ID and any partition ID
+	PID    byte
+}
+
+// 🟦 Lookup tables
+
+// 👇 structured fields that carry a partition ID
+var partitioned = map[types.SFID]bool{
+	types.READ_PARTITION: true,
+	types.SET_REPLY_MODE: true,
+}
+
+// 🟦 Stringer implementation
+
+func (s SFld) String() string {
+	if s.HasPID {
+		return fmt.Sprintf("{ID %#02x, PID %#02x, Info % #x}", byte(s.ID), s.PID, s.Info)
+	}
+	return fmt.Sprintf("{ID %#02x, Info % #x}", byte(s.ID), s.Info)
+}
+
+// 🟦 Helpers
+
+// 👁️ Introduction  pp 5-4 to 5-5
+func decodeSFlds(s *stream) ([]SFld, error) {
+	sflds := make([]SFld, 0)
+	for s.hasNext() {
+		at := s.ix
+		raw, err := s.nextSlice(2, "SFld length")
+		if err != nil {
+			return sflds, err
+		}
+		length := int(raw[0])<<8 | int(raw[1])
+		id, err := s.next("SFld ID")
+		if err != nil {
+			return sflds, err
+		}
+		sfld := SFld{At: at, ID: types.SFID(id)}
+		// TODO 🔥 a browser proxy may leave a partition ID of 0xfF doubled,
+		//    as if it were still telnet IAC-escaped, so we tolerate that
+		if next, ok := s.peek(); ok && next == 0xfF && s.ix+1 < len(s.chars) && s.chars[s.ix+1] == 0xfF {
+			s.ix++
+		}
+		// 👇 a zero length can indicate the last field
+		var info []byte
+		switch {
+
+		case length == 0:
+			info = s.rest()
+
+		case length < 3:
+			return sflds, &Error{At: at, Msg: fmt.Sprintf("SFld length %d invalid", length)}
+
+		default:
+			if info, err = s.nextSlice(length-3, fmt.Sprintf("SFld %s", sfld.ID)); err != nil {
+				return sflds, err
+			}
+		}
+		// 👇 split off the partition ID, if the field has one
+		if partitioned[sfld.ID] && len(info) > 0 {
+			sfld.HasPID = true
+			sfld.PID = info[0]
+			info = info[1:]
+		}
+		sfld.Info = info
+		sflds = append(sflds, sfld)
+	}
+	return sflds, nil
+}
@@ -0,0 +1,67 @@
+package datastream
+
+import (
+	"emulator/types"
+	"emulator/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSFlds(t *testing.T) {
+	raw := []any{
+		[]byte{0x00, 0x06},
+		types.READ_PARTITION,
+		[]byte{0xff, 0x02, 0x03},
+		[]byte{0x00, 0x05},
+		types.QUERY_REPLY,
+		[]byte{0x04, 0x05},
+	}
+	sflds, err := decodeSFlds(newStream(utils.Flatten2Bytes(raw, strings.ToUpper)))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sflds), "two SFlds found")
+	assert.Equal(t, SFld{At: 0, HasPID: true, ID: types.READ_PARTITION, Info: []byte{0x02, 0x03}, PID: 0xff}, sflds[0])
+	assert.Equal(t, SFld{At: 6, ID: types.QUERY_REPLY, Info: []byte{0x04, 0x05}}, sflds[1])
+
+	t.Run("a zero length means the rest of the record", func(t *testing.T) {
+		sflds, err := decodeSFlds(newStream([]byte{0x00, 0x00, byte(types.SET_REPLY_MODE), 0x00, 0x02}))
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x00), sflds[0].PID)
+		assert.Equal(t, []byte{0x02}, sflds[0].Info)
+	})
+
+	t.Run("a doubled 0xff partition ID is tolerated", func(t *testing.T) {
+		sflds, err := decodeSFlds(newStream([]byte{0x00, 0x05, byte(types.READ_PARTITION), 0xff, 0xff, 0x02}))
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0xff), sflds[0].PID)
+		assert.Equal(t, []byte{byte(types.Q)}, sflds[0].Info)
+	})
+
+	t.Run("bad lengths are errors", func(t *testing.T) {
+		_, err := decodeSFlds(newStream([]byte{0x00, 0x02, byte(types.QUERY_REPLY)}))
+		assert.EqualError(t, err, "datastream: SFld length 2 invalid at offset 0")
+		_, err = decodeSFlds(newStream([]byte{0x00, 0x08, byte(types.QUERY_REPLY), 0x01}))
+		assert.EqualError(t, err, "datastream: SFld QUERY_REPLY truncated at offset 3")
+	})
+}
+
+func TestSFldString(t *testing.T) {
+	assert.Equal(t, "{ID 0x01, PID 0xff, Info 0x02}", SFld{HasPID: true, ID: types.READ_PARTITION, Info: []byte{0x02}, PID: 0xff}.String())
+	assert.Equal(t, "{ID 0x81, Info 0x80}", SFld{ID: types.QUERY_REPLY, Info: []byte{0x80}}.String())
+}
+
+func FuzzDecodeSFlds(f *testing.F) {
+	f.Add([]byte{0x00, 0x06, byte(types.READ_PARTITION), 0xff, 0x02, 0x03})
+	f.Add([]byte{0x00, 0x05, byte(types.READ_PARTITION), 0xff, 0xff, 0x02})
+	f.Add([]byte{0x00, 0x00, byte(types.SET_REPLY_MODE), 0x00, 0x02})
+	f.Fuzz(func(t *testing.T, chars []byte) {
+		sflds, err := decodeSFlds(newStream(chars))
+		for _, sfld := range sflds {
+			assert.LessOrEqual(t, sfld.At, len(chars))
+		}
+		if err != nil {
+			assert.IsType(t, &Error{}, err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package datastream
+
+import (
+	"fmt"
+)
+
+// 🟧 Cursor over the bytes of a single record
+
+type stream struct {
+	chars []byte
+	ix    int
+}
+
+// 🟧 Decoding error, located by its offset in the record
+
+type Error struct {
+	At  int
+	Msg string
+	// 👇 the record ran out, rather than holding a bad value
+	Truncated bool
+}
+
+// 🟦 Constructor
+
+func newStream(chars []byte) *stream {
+	s := new(stream)
+	s.chars = chars
+	return s
+}
+
+// 🟦 Error implementation
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("datastream: %s at offset %d", e.Msg, e.At)
+}
+
+// 🟦 Helpers
+
+func (s *stream) corrupt(what string) error {
+	return &Error{At: s.ix, Msg: fmt.Sprintf("%s truncated", what), Truncated: true}
+}
+
+func (s *stream) hasNext() bool {
+	return s.ix < len(s.chars)
+}
+
+func (s *stream) next(what string) (byte, error) {
+	if !s.hasNext() {
+		return 0, s.corrupt(what)
+	}
+	char := s.chars[s.ix]
+	s.ix++
+	return char, nil
+}
+
+func (s *stream) nextSlice(count int, what string) ([]byte, error) {
+	if s.ix+count > len(s.chars) {
+		return nil, s.corrupt(what)
+	}
+	slice := s.chars[s.ix : s.ix+count]
+	s.ix += count
+	return slice, nil
+}
+
+func (s *stream) peek() (byte, bool) {
+	if !s.hasNext() {
+		return 0, false
+	}
+	return s.chars[s.ix], true
+}
+
+func (s *stream) rest() []byte {
+	rest := s.chars[s.ix:]
+	s.ix = len(s.chars)
+	return rest
+}
@@ -0,0 +1,30 @@
+package datastream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	s := newStream([]byte{0x01, 0x02, 0x03})
+
+	char, err := s.next("first")
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x01), char)
+
+	next, ok := s.peek()
+	assert.True(t, ok)
+	assert.Equal(t, byte(0x02), next)
+
+	_, err = s.nextSlice(3, "slice")
+	assert.EqualError(t, err, "datastream: slice truncated at offset 1", "a failed read does not advance")
+
+	assert.Equal(t, []byte{0x02, 0x03}, s.rest())
+	assert.False(t, s.hasNext())
+
+	_, err = s.next("last")
+	var corrupt *Error
+	assert.ErrorAs(t, err, &corrupt)
+	assert.Equal(t, 3, corrupt.At)
+}
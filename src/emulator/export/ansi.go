@@ -0,0 +1,48 @@
+package export
+
+import (
+	"emulator/utils"
+	"fmt"
+	"strings"
+)
+
+// 🟧 Export a snapshot as ANSI-colored text for a terminal,
+//    using 24-bit color straight from the CLUT
+
+func ANSI(s *Snapshot) string {
+	var b strings.Builder
+	bg := sgrColor(48, s.BgColor)
+	for row := uint(1); row <= s.Rows; row++ {
+		for _, r := range runsOf(s.Row(row), looksSame) {
+			b.WriteString(sgr(r.cell, bg))
+			b.WriteString(string(r.runes))
+		}
+		// 👇 reset at the end of each row, so a log stays readable
+		b.WriteString("\x1b[0m\n")
+	}
+	return b.String()
+}
+
+// 🟦 Helpers
+
+func sgr(c Cell, bg string) string {
+	codes := []string{"0", bg, sgrColor(38, c.Color)}
+	if bright(c) {
+		codes = append(codes, "1")
+	}
+	if underscore(c) {
+		codes = append(codes, "4")
+	}
+	if c.Attrs.Blink {
+		codes = append(codes, "5")
+	}
+	if c.Attrs.Reverse {
+		codes = append(codes, "7")
+	}
+	return fmt.Sprintf("\x1b[%sm", strings.Join(codes, ";"))
+}
+
+func sgrColor(code int, hex string) string {
+	rgba := utils.HexColor(hex)
+	return fmt.Sprintf("%d;2;%d;%d;%d", code, rgba.R, rgba.G, rgba.B)
+}
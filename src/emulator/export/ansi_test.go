@@ -0,0 +1,16 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANSI(t *testing.T) {
+	lines := strings.Split(ANSI(mockSnapshot()), "\n")
+	assert.Len(t, lines, 13)
+	// 👇 24-bit red in reverse video, on the CLUT background
+	assert.Contains(t, lines[0], "\x1b[0;48;2;32;32;32;38;2;255;0;0;7m Test screen")
+	assert.True(t, strings.HasSuffix(lines[0], "\x1b[0m"))
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// 🟧 Export a snapshot as semantic HTML: a <pre> holding a <span>
+//    for each field, within which runs of cells carry classes for
+//    their attributes and take their colors from the CLUT
+
+func HTML(s *Snapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<pre class=\"screen\" style=\"background-color: %s\">\n", s.BgColor)
+	// 👇 a field may wrap from one row to the next, or from the
+	//    end of the buffer to the start
+	var fld *Cell
+	for ix := range s.Cells {
+		if s.Cells[ix].FldStart {
+			fld = &s.Cells[ix]
+		}
+	}
+	// 🔥 the attribute itself is always a run of its own
+	same := func(x, y Cell) bool { return !x.FldStart && !y.FldStart && looksSame(x, y) }
+	for row := uint(1); row <= s.Rows; row++ {
+		cells := s.Row(row)
+		if fld != nil && !cells[0].FldStart {
+			b.WriteString(fldSpan(*fld))
+		}
+		for _, r := range runsOf(cells, same) {
+			if r.cell.FldStart {
+				if fld != nil && r.col > 1 {
+					b.WriteString("</span>")
+				}
+				fld = &r.cell
+				b.WriteString(fldSpan(*fld))
+			}
+			fmt.Fprintf(&b, "<span%s style=\"color: %s\">%s</span>", classesOf(r.cell), r.cell.Color, html.EscapeString(string(r.runes)))
+		}
+		if fld != nil {
+			b.WriteString("</span>")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("</pre>\n")
+	return b.String()
+}
+
+// 🟦 Helpers
+
+func fldSpan(sf Cell) string {
+	classes := []string{"fld"}
+	classes = append(classes, map[bool]string{true: "protected", false: "unprotected"}[sf.Attrs.Protected])
+	if sf.Attrs.Numeric {
+		classes = append(classes, "numeric")
+	}
+	if sf.Attrs.Hidden {
+		classes = append(classes, "hidden")
+	}
+	if sf.Attrs.MDT {
+		classes = append(classes, "modified")
+	}
+	return fmt.Sprintf("<span class=\"%s\">", strings.Join(classes, " "))
+}
+
+func classesOf(c Cell) string {
+	classes := make([]string, 0)
+	if c.FldStart {
+		classes = append(classes, "attr")
+	}
+	if bright(c) {
+		classes = append(classes, "intensified")
+	}
+	if underscore(c) {
+		classes = append(classes, "underscore")
+	}
+	if c.Attrs.Blink {
+		classes = append(classes, "blink")
+	}
+	if c.Attrs.Reverse {
+		classes = append(classes, "reverse")
+	}
+	if len(classes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" class=\"%s\"", strings.Join(classes, " "))
+}
@@ -0,0 +1,20 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	html := HTML(mockSnapshot())
+	assert.True(t, strings.HasPrefix(html, "<pre class=\"screen\" style=\"background-color: #202020\">"))
+	assert.Contains(t, html, "<span class=\"reverse\" style=\"color: #ff0000\">Test screen")
+	assert.Contains(t, html, "<span class=\"attr reverse\" style=\"color: #ff0000\"> </span>")
+	assert.Contains(t, html, "<span class=\"fld unprotected\">")
+	assert.NotContains(t, html, "<span class=\"fld protected\"></span>", "no empty fields")
+	// 👇 the last field wraps around to the top of the screen
+	assert.Contains(t, html, ">\n<span class=\"fld protected\"><span style=\"color: #4169e1\">         </span>")
+	assert.Equal(t, strings.Count(html, "<span"), strings.Count(html, "</span>"))
+}
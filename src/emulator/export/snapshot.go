@@ -0,0 +1,104 @@
+package export
+
+import (
+	"emulator/conv"
+	"emulator/core"
+	"emulator/types"
+)
+
+// 🟧 A snapshot of the buffer, frozen so it can be exported at leisure
+//    and independently of the emulator that produced it
+
+type Snapshot struct {
+	BgColor  string
+	Cells    []Cell
+	Cols     uint
+	CursorAt uint
+	Rows     uint
+}
+
+// 👇 a cell as it appears, with its color resolved from the active CLUT
+
+type Cell struct {
+	Attrs    types.Attrs
+	Color    string
+	FldStart bool
+	Rune     rune
+}
+
+// 🟦 Constructor
+
+func NewSnapshot(emu *core.Emulator) *Snapshot {
+	s := &Snapshot{
+		BgColor: emu.Cfg.BgColor,
+		Cells:   make([]Cell, emu.Buf.Len()),
+		Cols:    emu.Cfg.Cols,
+		Rows:    emu.Cfg.Rows,
+	}
+	if emu.State.Status != nil {
+		s.CursorAt = emu.State.Status.CursorAt
+	}
+	for addr := range s.Cells {
+		cell := emu.Buf.MustPeek(uint(addr))
+		c := Cell{
+			Attrs:    *cell.Attrs,
+			Color:    emu.Cfg.ColorOf(cell.Attrs),
+			FldStart: cell.IsFldStart(),
+			Rune:     ' ',
+		}
+		// 👇 nulls, attributes and hidden data all appear blank
+		if !c.FldStart && !c.Attrs.Hidden && cell.Char > 0x40 {
+			c.Rune = conv.E2RuneCP(emu.Cfg.CodePage, cell.Attrs.LCID, cell.Char)
+		}
+		s.Cells[addr] = c
+	}
+	return s
+}
+
+// 🟦 Public functions
+
+// 👇 the cells of a row, one-based as everywhere else
+func (s *Snapshot) Row(row uint) []Cell {
+	from := (row - 1) * s.Cols
+	return s.Cells[from : from+s.Cols]
+}
+
+// 🟦 Helpers
+
+// 👇 cells with the same look are drawn together as a run
+type run struct {
+	cell  Cell
+	col   uint
+	runes []rune
+}
+
+func runsOf(cells []Cell, same func(a, b Cell) bool) []run {
+	runs := make([]run, 0)
+	for ix, cell := range cells {
+		n := len(runs)
+		if n > 0 && same(runs[n-1].cell, cell) {
+			runs[n-1].runes = append(runs[n-1].runes, cell.Rune)
+		} else {
+			runs = append(runs, run{cell: cell, col: uint(ix) + 1, runes: []rune{cell.Rune}})
+		}
+	}
+	return runs
+}
+
+// 👇 the attributes that change how a cell looks
+func looksSame(a, b Cell) bool {
+	return a.Color == b.Color &&
+		bright(a) == bright(b) &&
+		a.Attrs.Blink == b.Attrs.Blink &&
+		a.Attrs.Reverse == b.Attrs.Reverse &&
+		underscore(a) == underscore(b)
+}
+
+func bright(c Cell) bool {
+	return c.Attrs.Highlight || c.Attrs.Intensify
+}
+
+// 🔥 attribute cells are never underscored, just as on the screen
+func underscore(c Cell) bool {
+	return c.Attrs.Underscore && !c.FldStart
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"emulator/core"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 the example screen, with "Test" in reverse red
+func mockSnapshot() *Snapshot {
+	emu := core.MockEmulator(12, 40).Initialize()
+	attrs := core.MockAttrsMap{{Row: 1, Col: 10}: &types.Attrs{Color: types.RED, Reverse: true}}
+	emu.Bus.PubOutbound(core.MockStream(types.EW, types.WCC{Unlock: true}, core.MockExampleImg, attrs))
+	return NewSnapshot(emu)
+}
+
+func TestNewSnapshot(t *testing.T) {
+	s := mockSnapshot()
+	assert.Len(t, s.Cells, 12*40)
+	assert.Equal(t, "#202020", s.BgColor)
+	row := s.Row(1)
+	assert.True(t, row[9].FldStart)
+	assert.Equal(t, ' ', row[9].Rune, "attributes are blank")
+	assert.Equal(t, 'T', row[10].Rune)
+	// 👇 colors come from the CLUT
+	assert.Equal(t, "#ff0000", row[10].Color)
+	assert.True(t, row[10].Attrs.Reverse)
+}
+
+func TestRunsOf(t *testing.T) {
+	runs := runsOf(mockSnapshot().Row(3), looksSame)
+	// 👇 the unprotected field looks different from the protected ones
+	assert.Len(t, runs, 3)
+	assert.Equal(t, uint(21), runs[1].col)
+	assert.Equal(t, "¶What is your name ?", "¶"+string(runs[0].runes[1:]))
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// 🟧 Export a snapshot as SVG, on a fixed grid of cells so that
+//    any monospaced font will do
+
+const (
+	cellHeight = 20
+	cellWidth  = 10
+	fontSize   = 16
+)
+
+func SVG(s *Snapshot) string {
+	var b strings.Builder
+	w, h := s.Cols*cellWidth, s.Rows*cellHeight
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", w, h, w, h)
+	fmt.Fprintf(&b, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", s.BgColor)
+	fmt.Fprintf(&b, "<g font-family=\"monospace\" font-size=\"%d\" xml:space=\"preserve\">\n", fontSize)
+	for row := uint(1); row <= s.Rows; row++ {
+		y := (row - 1) * cellHeight
+		for _, r := range runsOf(s.Row(row), looksSame) {
+			x := (r.col - 1) * cellWidth
+			width := uint(len(r.runes)) * cellWidth
+			fg := r.cell.Color
+			// 👇 reverse video is a block of color behind the text
+			if r.cell.Attrs.Reverse {
+				fmt.Fprintf(&b, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", x, y, width, cellHeight, fg)
+				fg = s.BgColor
+			}
+			text := strings.TrimRight(string(r.runes), " ")
+			if text == "" && !underscore(r.cell) {
+				continue
+			}
+			attrs := ""
+			if bright(r.cell) {
+				attrs += " font-weight=\"bold\""
+			}
+			if underscore(r.cell) {
+				attrs += " text-decoration=\"underline\""
+			}
+			// 🔥 pin the width of the text so it stays on the grid
+			fmt.Fprintf(&b, "<text x=\"%d\" y=\"%d\" fill=\"%s\" textLength=\"%d\" lengthAdjust=\"spacingAndGlyphs\"%s>%s</text>\n",
+				x, y+cellHeight*3/4, fg, width, attrs, html.EscapeString(string(r.runes)))
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+	return b.String()
+}
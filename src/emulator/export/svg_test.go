@@ -0,0 +1,26 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSVG(t *testing.T) {
+	svg := SVG(mockSnapshot())
+	assert.True(t, strings.HasPrefix(svg, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"400\" height=\"240\""))
+	// 👇 reverse video is a block of red, with text on the background
+	assert.Contains(t, svg, "<rect x=\"90\" y=\"0\" width=\"310\" height=\"20\" fill=\"#ff0000\"/>")
+	assert.Contains(t, svg, "fill=\"#202020\" textLength=\"310\" lengthAdjust=\"spacingAndGlyphs\"> Test screen")
+	// 👇 and it is well-formed XML
+	d := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		_, err := d.Token()
+		if err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+}
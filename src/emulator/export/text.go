@@ -0,0 +1,32 @@
+package export
+
+import (
+	"strings"
+)
+
+// 🟧 Export a snapshot as plain text, one line per row
+
+// 👇 optionally, field attributes are marked as in the test mocks:
+//    ¶ for a protected field and ■ for an unprotected one
+
+func Text(s *Snapshot, markers bool) string {
+	var b strings.Builder
+	for row := uint(1); row <= s.Rows; row++ {
+		for _, cell := range s.Row(row) {
+			switch {
+
+			case markers && cell.FldStart && cell.Attrs.Protected:
+				b.WriteRune('¶')
+
+			case markers && cell.FldStart:
+				b.WriteRune('■')
+
+			default:
+				b.WriteRune(cell.Rune)
+
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	s := mockSnapshot()
+
+	t.Run("plain text", func(t *testing.T) {
+		lines := strings.Split(Text(s, false), "\n")
+		assert.Len(t, lines, 13, "12 rows plus a final newline")
+		assert.Equal(t, "          Test screen                   ", lines[0])
+	})
+
+	t.Run("with field markers", func(t *testing.T) {
+		lines := strings.Split(Text(s, true), "\n")
+		assert.Equal(t, "¶What is your name ?■                  ¶", lines[2])
+	})
+}
@@ -12,4 +12,62 @@ var (
 	NormalFontEmbed []byte
 	//go:embed JuliaMono-Bold.ttf.wasm
 	BoldFontEmbed []byte
+	//go:embed 3270Medium.ttf.wasm
+	OIAFontEmbed []byte
+	//go:embed IBMPlexMono-Regular.ttf.wasm
+	plexNormal []byte
+	//go:embed IBMPlexMono-Bold.ttf.wasm
+	plexBold []byte
+	//go:embed NotoSansMono-Regular.ttf.wasm
+	notoNormal []byte
+	//go:embed NotoSansMono-Bold.ttf.wasm
+	notoBold []byte
 )
+
+// 🟧 A family of normal and bold fonts, as TTF bytes
+
+type Family struct {
+	Bold   []byte
+	Name   string
+	Normal []byte
+}
+
+// 👇 JuliaMono is the default, as it has the widest coverage (APL etc)
+var DefaultFamily = Family{Bold: BoldFontEmbed, Name: "JuliaMono", Normal: NormalFontEmbed}
+
+// 🔥 3270 has no bold, so its normal face does double duty
+var families = map[string]Family{
+	"3270":         {Bold: OIAFontEmbed, Name: "3270", Normal: OIAFontEmbed},
+	"IBMPlexMono":  {Bold: plexBold, Name: "IBMPlexMono", Normal: plexNormal},
+	"JuliaMono":    DefaultFamily,
+	"NotoSansMono": {Bold: notoBold, Name: "NotoSansMono", Normal: notoNormal},
+}
+
+// 🟦 Public functions
+
+func FamilyOf(name string) (Family, bool) {
+	f, ok := families[name]
+	return f, ok
+}
+
+func FamilyNames() []string {
+	return []string{"3270", "IBMPlexMono", "JuliaMono", "NotoSansMono"}
+}
+
+// 👇 true if the TTF is one of ours, and so lives as long as we do
+func IsEmbedded(ttf []byte) bool {
+	if len(ttf) == 0 {
+		return false
+	}
+	for _, embed := range [][]byte{NormalFontEmbed, BoldFontEmbed, OIAFontEmbed, plexNormal, plexBold, notoNormal, notoBold} {
+		if len(embed) == len(ttf) && &embed[0] == &ttf[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// 👇 a user-supplied TTF without a bold variant
+func UserFamily(name string, ttf []byte) Family {
+	return Family{Bold: ttf, Name: name, Normal: ttf}
+}
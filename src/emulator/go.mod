@@ -3,10 +3,10 @@ module emulator
 go 1.25.0
 
 require (
-	github.com/fogleman/gg v1.3.0
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/google/go-cmp v0.7.0
 	github.com/jedib0t/go-pretty/v6 v6.7.2
+	github.com/racingmars/go3270 v0.9.5
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/image v0.33.0
 )
@@ -0,0 +1,53 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// 🟧 A problem found in an outbound record
+
+type Issue struct {
+	At    int   // 👈 offset in the record
+	Check Check // 👈 so tests can allow some
+	Msg   string
+}
+
+type Check int
+
+// 🟦 Lookup tables
+
+const (
+	ADDRESS Check = iota
+	DEGENERATE
+	GE
+	OVERLAP
+	PAIRS
+	TRUNCATED
+	UNKNOWN
+	WRAP
+)
+
+var checks = map[Check]string{
+	ADDRESS:    "ADDRESS",
+	DEGENERATE: "DEGENERATE",
+	GE:         "GE",
+	OVERLAP:    "OVERLAP",
+	PAIRS:      "PAIRS",
+	TRUNCATED:  "TRUNCATED",
+	UNKNOWN:    "UNKNOWN",
+	WRAP:       "WRAP",
+}
+
+// 🟦 Stringer implementation
+
+func CheckFor(c Check) string {
+	return checks[c]
+}
+
+func (c Check) String() string {
+	return CheckFor(c)
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("offset %d: %s %s", i.At, i.Check, i.Msg)
+}
@@ -0,0 +1,13 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueString(t *testing.T) {
+	issue := Issue{At: 2, Check: ADDRESS, Msg: "SBA address 1920 outside 24x80 screen"}
+	assert.Equal(t, "offset 2: ADDRESS SBA address 1920 outside 24x80 screen", issue.String())
+	assert.Equal(t, "WRAP", WRAP.String())
+}
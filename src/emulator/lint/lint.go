@@ -0,0 +1,306 @@
+package lint
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/datastream"
+	"emulator/types"
+	"emulator/utils"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// 🟧 Check an outbound (3270 <- app) record, for host app developers
+
+type linter struct {
+	addr    uint
+	chars   []byte
+	cols    uint
+	fields  map[uint]int // 👈 field attributes written, by offset of their order
+	inFld   bool
+	issues  []Issue
+	known   bool // 👈 false when we can't tell the buffer address
+	rows    uint
+	wrapped bool // 👈 the buffer address has wrapped, but not yet been written
+}
+
+// 🟦 Lookup tables
+
+// 👇 control chars that are data, not orders
+var controls = map[byte]bool{
+	0x00: true, // 👈 NUL
+	0x0c: true, // 👈 FF
+	0x0d: true, // 👈 CR
+	0x15: true, // 👈 NL
+	0x19: true, // 👈 EM
+	0x1c: true, // 👈 DUP
+	0x1e: true, // 👈 FM
+	0x3f: true, // 👈 SUB
+}
+
+// 👇 read partition types the emulator answers
+var queries = map[types.Command]bool{
+	types.Q:   true,
+	types.QL:  true,
+	types.RB:  true,
+	types.RM:  true,
+	types.RMA: true,
+}
+
+// 👇 structured fields the emulator handles
+var sflds = map[types.SFID]bool{
+	types.READ_PARTITION: true,
+	types.SET_REPLY_MODE: true,
+}
+
+// 🟦 Public functions
+
+// 👇 every issue found, in offset order, or none if the record is clean
+func Record(chars []byte, rows, cols uint) []Issue {
+	chars, _ = bytes.CutSuffix(chars, types.LT)
+	l := &linter{
+		chars:  chars,
+		cols:   cols,
+		fields: make(map[uint]int),
+		issues: make([]Issue, 0),
+		known:  true,
+		rows:   rows,
+	}
+	out, err := datastream.DecodeOutbound(chars)
+	// 👇 the command
+	switch {
+
+	case len(chars) == 0:
+
+	case types.CommandFor(out.Cmd) == "" || out.Cmd == types.Q || out.Cmd == types.QL:
+		l.issue(0, UNKNOWN, "command %#02x unknown", byte(out.Cmd))
+
+	case out.WCC == nil && (out.Cmd == types.EW || out.Cmd == types.EWA || out.Cmd == types.W):
+		l.issue(1, TRUNCATED, "%s without WCC", out.Cmd)
+	}
+	// 👇 what follows it
+	for _, order := range out.Orders {
+		l.order(order)
+	}
+	l.degenerate()
+	for _, sfld := range out.SFlds {
+		l.sfld(sfld)
+	}
+	// 👇 the decoder stops at the first truncation
+	var corrupt *datastream.Error
+	if errors.As(err, &corrupt) {
+		l.issue(corrupt.At, TRUNCATED, "%s", corrupt.Msg)
+	}
+	slices.SortStableFunc(l.issues, func(a, b Issue) int { return a.At - b.At })
+	return l.issues
+}
+
+// 🟦 Orders
+
+func (l *linter) order(order datastream.Order) {
+	switch order.Order {
+
+	case types.EUA:
+		if l.validAddr(order) {
+			l.wrapsTo(order, order.Addr)
+			l.addr, l.known, l.wrapped = order.Addr, true, false
+		}
+
+	case types.GE:
+		l.ge(order)
+		l.write(order, order.At)
+
+	case types.IC:
+
+	case types.MF:
+		l.pairs(order, true)
+		l.advance()
+
+	case types.PT:
+		// TODO 🔥 PT not handled by the emulator, which is fatal
+		l.issue(order.At, UNKNOWN, "PT not supported")
+		l.known = false
+
+	case types.RA:
+		if order.GE {
+			l.ge(order)
+		}
+		if l.validAddr(order) {
+			l.ra(order)
+		}
+
+	case types.SA:
+		l.pairs(order, false)
+
+	case types.SBA:
+		if l.validAddr(order) {
+			l.addr, l.known, l.wrapped = order.Addr, true, false
+		} else {
+			l.known = false
+		}
+
+	case types.SF:
+		l.field(order)
+
+	case types.SFE:
+		l.pairs(order, true)
+		l.field(order)
+
+	case datastream.DATA:
+		for ix, char := range order.Chars {
+			if char < 0x40 && !controls[char] {
+				l.issue(order.At+ix, UNKNOWN, "order %#02x unknown", char)
+			}
+			l.write(order, order.At+ix)
+		}
+	}
+}
+
+func (l *linter) field(order datastream.Order) {
+	l.inFld = true
+	if l.known {
+		l.wrap(order)
+		if at, ok := l.fields[l.addr]; ok {
+			l.issue(order.At, OVERLAP, "%s at %s replaces the field written at offset %d", order.Order, l.rc(l.addr), at)
+		}
+		l.fields[l.addr] = order.At
+	}
+	l.advance()
+}
+
+func (l *linter) ge(order datastream.Order) {
+	if !l.inFld {
+		l.issue(order.At, GE, "GE outside a field")
+	}
+}
+
+func (l *linter) pairs(order datastream.Order, fld bool) {
+	seen := make(map[types.Typecode]bool)
+	for ix, pair := range order.Pairs {
+		switch {
+
+		case types.TypecodeFor(pair.Type) == "":
+			l.issue(order.At, PAIRS, "%s pair %d type %#02x unknown, is the pair count wrong?", order.Order, ix+1, byte(pair.Type))
+
+		case seen[pair.Type]:
+			l.issue(order.At, PAIRS, "%s pair %d repeats %s", order.Order, ix+1, pair.Type)
+
+		case pair.Type == types.BASIC && !fld:
+			l.issue(order.At, PAIRS, "%s pair %d can't set BASIC", order.Order, ix+1)
+		}
+		seen[pair.Type] = true
+	}
+}
+
+func (l *linter) ra(order datastream.Order) {
+	if l.known {
+		l.wrapsTo(order, order.Addr)
+		// 👇 RA writes up to, but not including, the stop address
+		for addr := l.addr; ; {
+			l.overwrite(order, order.At, addr)
+			addr = (addr + 1) % l.size()
+			if addr == order.Addr {
+				break
+			}
+		}
+	}
+	l.addr, l.known, l.wrapped = order.Addr, true, false
+}
+
+func (l *linter) sfld(sfld datastream.SFld) {
+	switch {
+
+	case !sflds[sfld.ID]:
+		l.issue(sfld.At, UNKNOWN, "SFld %s not supported", sfld.ID)
+
+	case sfld.ID == types.READ_PARTITION && (len(sfld.Info) == 0 || !queries[types.Command(sfld.Info[0])]):
+		l.issue(sfld.At, UNKNOWN, "READ_PARTITION type unknown")
+	}
+}
+
+// 🟦 Buffer addresses
+
+func (l *linter) advance() {
+	if l.known {
+		l.addr++
+		if l.addr == l.size() {
+			l.addr, l.wrapped = 0, true
+		}
+	}
+}
+
+func (l *linter) degenerate() {
+	addrs := make([]uint, 0, len(l.fields))
+	for addr := range l.fields {
+		addrs = append(addrs, addr)
+	}
+	slices.Sort(addrs)
+	for ix, addr := range addrs {
+		next := addrs[(ix+1)%len(addrs)]
+		if len(addrs) > 1 && next == (addr+1)%l.size() {
+			l.issue(l.fields[addr], DEGENERATE, "field at %s has no data positions", l.rc(addr))
+		}
+	}
+}
+
+func (l *linter) overwrite(order datastream.Order, at int, addr uint) {
+	if start, ok := l.fields[addr]; ok {
+		l.issue(at, OVERLAP, "%s at %s overwrites the field written at offset %d", nameOf(order), l.rc(addr), start)
+		delete(l.fields, addr)
+	}
+}
+
+func (l *linter) rc(addr uint) string {
+	return fmt.Sprintf("row %d col %d", addr/l.cols+1, addr%l.cols+1)
+}
+
+func (l *linter) size() uint {
+	return l.rows * l.cols
+}
+
+func (l *linter) validAddr(order datastream.Order) bool {
+	// 👇 12-bit addresses are encoded as two graphic chars
+	raw := l.chars[order.At+1 : order.At+3]
+	if !bytes.Equal(raw, conv.Addr2Bytes(order.Addr)) {
+		l.issue(order.At, ADDRESS, "%s address % #x is not 12-bit encoded", order.Order, raw)
+		return false
+	}
+	if order.Addr >= l.size() {
+		l.issue(order.At, ADDRESS, "%s address %d outside %dx%d screen", order.Order, order.Addr, l.rows, l.cols)
+		return false
+	}
+	return true
+}
+
+func (l *linter) wrap(order datastream.Order) {
+	if l.wrapped {
+		l.issue(order.At, WRAP, "%s wraps to row 1 col 1", nameOf(order))
+		l.wrapped = false
+	}
+}
+
+func (l *linter) wrapsTo(order datastream.Order, stop uint) {
+	// 👇 stopping at row 1 col 1 just fills to the end of the screen
+	if l.known && stop != 0 && stop <= l.addr {
+		l.issue(order.At, WRAP, "%s from %s to %s wraps", order.Order, l.rc(l.addr), l.rc(stop))
+	}
+}
+
+func (l *linter) write(order datastream.Order, at int) {
+	if l.known {
+		l.wrap(order)
+		l.overwrite(order, at, l.addr)
+	}
+	l.advance()
+}
+
+// 🟦 Helpers
+
+func nameOf(order datastream.Order) string {
+	return utils.Ternary(order.Order == datastream.DATA, "data", order.Order.String())
+}
+
+func (l *linter) issue(at int, check Check, msg string, args ...any) {
+	l.issues = append(l.issues, Issue{At: at, Check: check, Msg: fmt.Sprintf(msg, args...)})
+}
@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"emulator/conv"
+	"emulator/datastream"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	prot := &types.Attrs{Protected: true}
+
+	t.Run("a clean record has no issues", func(t *testing.T) {
+		chars := datastream.NewBuilder(24, 80).
+			Command(types.EW).WCC(types.WCC{Unlock: true}).
+			SBA(1, 1).SF(prot).Text("Name:").
+			SFE(&types.Attrs{Color: types.RED}).IC().GE(0xad).
+			SBA(1, 20).SF(prot).RA(1, 40, '-').
+			SBA(24, 79).SF(prot).Text("x").
+			MustBytes()
+		assert.Empty(t, Record(chars, 24, 80))
+	})
+
+	t.Run("every issue is reported with its offset", func(t *testing.T) {
+		row2, row3 := conv.Addr2Bytes(80), conv.Addr2Bytes(160)
+		raw := []byte{byte(types.W), 0x00}
+		raw = append(raw, byte(types.SBA), 0x7f, 0x7f)                                // 👈 2: outside the screen
+		raw = append(raw, byte(types.SBA), 0x00, 0x10)                                // 👈 5: not 12-bit encoded
+		raw = append(raw, byte(types.GE), 0xad)                                       // 👈 8: outside a field
+		raw = append(raw, 0x02)                                                       // 👈 10: unknown order
+		raw = append(raw, byte(types.SBA), row3[0], row3[1], byte(types.SF), 0x60)    // 👈 14: no data positions
+		raw = append(raw, byte(types.SF), 0x60, 0xc1)                                 // 👈 16
+		raw = append(raw, byte(types.SBA), row2[0], row2[1], byte(types.SF), 0x60)    // 👈 22
+		raw = append(raw, byte(types.SBA), row2[0], row2[1], byte(types.SF), 0x60)    // 👈 27: replaces 22
+		raw = append(raw, 0xc1, byte(types.SA), byte(types.BASIC), 0x20)              // 👈 30: SA can't
+		raw = append(raw, byte(types.SFE), 0x02, byte(types.COLOR), 0xf2, 0xc1, 0xc2) // 👈 33: bad count
+		raw = append(raw, byte(types.SBA), 0x5d, 0x7f, 0xc1, 0xc2)                    // 👈 42: wraps
+		raw = append(raw, byte(types.RA), row2[0], row2[1])                           // 👈 47: truncated
+		issues := Record(raw, 24, 80)
+		checks := make([]Check, 0)
+		ats := make([]int, 0)
+		for _, issue := range issues {
+			checks = append(checks, issue.Check)
+			ats = append(ats, issue.At)
+		}
+		assert.Equal(t, []Check{ADDRESS, ADDRESS, GE, UNKNOWN, DEGENERATE, OVERLAP, PAIRS, PAIRS, WRAP, TRUNCATED}, checks)
+		assert.Equal(t, []int{2, 5, 8, 10, 14, 27, 30, 33, 42, 47}, ats)
+		assert.Equal(t, "SBA address 4095 outside 24x80 screen", issues[0].Msg)
+		assert.Equal(t, "SF at row 2 col 1 replaces the field written at offset 22", issues[5].Msg)
+		assert.Equal(t, "SFE pair 2 type 0xc1 unknown, is the pair count wrong?", issues[7].Msg)
+		assert.Equal(t, "data wraps to row 1 col 1", issues[8].Msg)
+		assert.Equal(t, "RA char truncated", issues[9].Msg)
+	})
+
+	t.Run("commands are checked", func(t *testing.T) {
+		assert.Equal(t, UNKNOWN, Record([]byte{0x01, 0x00}, 24, 80)[0].Check)
+		assert.Equal(t, "EW without WCC", Record([]byte{byte(types.EW)}, 24, 80)[0].Msg)
+		assert.Empty(t, Record([]byte{byte(types.RM)}, 24, 80))
+	})
+
+	t.Run("so are structured fields", func(t *testing.T) {
+		chars := datastream.NewBuilder(24, 80).
+			Command(types.WSF).
+			Query().
+			SFld(0x40, 0x00, 0xf5).
+			SFld(types.READ_PARTITION, 0xff, 0x99).
+			MustBytes()
+		issues := Record(chars, 24, 80)
+		assert.Len(t, issues, 2)
+		assert.Equal(t, "SFld 0x40 not supported", issues[0].Msg)
+		assert.Equal(t, "READ_PARTITION type unknown", issues[1].Msg)
+	})
+
+	t.Run("data written over a field attribute", func(t *testing.T) {
+		chars := datastream.NewBuilder(24, 80).
+			Command(types.EW).WCC(types.WCC{}).
+			SBA(1, 5).SF(prot).
+			SBA(1, 1).RA(1, 10, '*').
+			MustBytes()
+		issues := Record(chars, 24, 80)
+		assert.Len(t, issues, 1)
+		assert.Equal(t, OVERLAP, issues[0].Check)
+	})
+
+	t.Run("repeats that wrap", func(t *testing.T) {
+		chars := datastream.NewBuilder(24, 80).
+			Command(types.EW).WCC(types.WCC{}).
+			RA(1, 1, ' ').
+			SBA(24, 1).RA(1, 1, '-').
+			SBA(24, 1).EUA(2, 1).
+			MustBytes()
+		issues := Record(chars, 24, 80)
+		assert.Len(t, issues, 1, "only the EUA really wraps")
+		assert.Equal(t, "EUA from row 24 col 1 to row 2 col 1 wraps", issues[0].Msg)
+	})
+}
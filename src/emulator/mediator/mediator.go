@@ -5,12 +5,8 @@ import (
 	_ "embed"
 	"fmt"
 	"image"
-	"math"
 	"strconv"
 
-	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
-
 	"emulator/core"
 	"emulator/fonts"
 	"emulator/snapshots"
@@ -30,8 +26,10 @@ import (
 //    canvas whenever the context changes
 
 type Mediator struct {
-	bus *core.Bus
-	emu *core.Emulator
+	bus    *core.Bus
+	canvas js.Value
+	emu    *core.Emulator
+	scale  float64
 }
 
 // 👁️ go3270.ts
@@ -46,6 +44,9 @@ type Mediator struct {
 // args[7] dpi
 // 👇 for testing
 // args[8] testPage
+// 👇 optional
+// args[9] oia
+// args[10] font family name
 
 func NewGo3270(this js.Value, args []js.Value) any {
 	m := new(Mediator)
@@ -53,6 +54,7 @@ func NewGo3270(this js.Value, args []js.Value) any {
 	// 🔥 must subscribe BEFORE we create the emulator
 	m.bus.SubInbound(m.inbound)
 	m.bus.SubPanic(m.panic)
+	m.bus.SubProbe(m.probe)
 	m.bus.SubStatus(m.status)
 	// 👇 create and configure the emulator and its children
 	cfg := m.configure(args)
@@ -89,50 +91,49 @@ func (m *Mediator) configure(args []js.Value) *types.Config {
 	cols := uint(args[6].Int())
 	dpi := args[7].Float()
 	testpage := args[8].String()
+	// 🔥 optional, so older UIs still work
+	oia := len(args) > 9 && args[9].Bool()
+	family := fonts.DefaultFamily
+	if len(args) > 10 {
+		if f, ok := fonts.FamilyOf(args[10].String()); ok {
+			family = f
+		}
+	}
 	// 👇 constants
 	maxFPS := 30.0
 	paddedHeight := 1.5
 	paddedWidth := 1.1
 	tickMs := 333
-	// 👇 load the fonts
-	normalFont, _ := truetype.Parse(fonts.NormalFontEmbed)
-	normalFace := truetype.NewFace(normalFont, &truetype.Options{Size: fontSize, DPI: dpi /* , Hinting: font.HintingFull */})
-	boldFont, _ := truetype.Parse(fonts.BoldFontEmbed)
-	boldFace := truetype.NewFace(boldFont, &truetype.Options{Size: fontSize, DPI: dpi /* , Hinting: font.HintingFull */})
-	// 👇 measure the cell size
-	temp := gg.NewContext(100, 100)
-	temp.SetFontFace(boldFace)
-	fontWidth, fontHeight := temp.MeasureString("M")
-	// 👇 resize the canvas
-	canvasWidth := float64(cols) * math.Round(fontWidth*paddedWidth)
-	canvasHeight := float64(rows) * math.Round(fontHeight*paddedHeight)
-	wrapper := canvas.Get("parentNode")
-	wrapper.Get("style").Set("width", fmt.Sprintf("%fpx", canvasWidth))
-	wrapper.Get("style").Set("height", fmt.Sprintf("%fpx", canvasHeight))
-	canvas.Set("width", canvasWidth)
-	canvas.Set("height", canvasHeight)
-	// 👇 prepare the rendering surface
-	rgba := image.NewRGBA(image.Rect(0, 0, int(canvasWidth), int(canvasHeight)))
-	// 👇 kick off loops
-	m.rcLoop(canvas, rgba, maxFPS)
-	m.tickLoop(tickMs)
+	// 👇 load and measure the fonts
+	normalFace, boldFace, fontWidth, fontHeight, _ := core.NewFaces(family, fontSize, dpi)
 	// 👇 finally!
 	cfg := types.Config{
 		BgColor:      bgColor,
 		BoldFace:     &boldFace,
 		CLUT:         clut,
 		Cols:         cols,
+		DPI:          dpi,
+		Family:       family,
 		FontHeight:   fontHeight,
 		FontSize:     fontSize,
 		FontWidth:    fontWidth,
 		Monochrome:   monochrome,
 		NormalFace:   &normalFace,
+		OIA:          oia,
 		PaddedHeight: paddedHeight,
 		PaddedWidth:  paddedWidth,
-		RGBA:         rgba,
 		Rows:         rows,
 		Testpage:     testpage,
+		WheelDown:    types.PF8,
+		WheelUp:      types.PF7,
 	}
+	// 👇 prepare the rendering surface and size the canvas to fit
+	cfg.RGBA = image.NewRGBA(core.CanvasRect(&cfg))
+	m.canvas = canvas
+	m.sizeCanvas(&cfg)
+	// 👇 kick off loops
+	m.rcLoop(maxFPS)
+	m.tickLoop(tickMs)
 	return &cfg
 
 }
@@ -141,15 +142,91 @@ func (m *Mediator) configure(args []js.Value) *types.Config {
 
 func (m *Mediator) jsInterface() js.Value {
 	functions := map[string]any{
+		"breakpoint": js.FuncOf(func(this js.Value, args []js.Value) any {
+			// 👇 row, col one-based, then whether to set or clear it
+			addr := m.emu.Cfg.RC2Addr(uint(args[0].Int()), uint(args[1].Int()))
+			if !args[2].Bool() {
+				m.emu.Dbg.Unbreak(addr)
+				return true
+			}
+			return m.emu.Dbg.Break(addr)
+		}),
 		"close": js.FuncOf(func(this js.Value, args []js.Value) any {
 			m.close()
 			return nil
 		}),
+		"continue": js.FuncOf(func(this js.Value, args []js.Value) any {
+			step, ok := m.emu.Dbg.Continue()
+			return m.step(step, ok)
+		}),
+		"copy": js.FuncOf(func(this js.Value, args []js.Value) any {
+			from, ok1 := m.emu.Scr.AddrAt(args[0].Float()*m.scale, args[1].Float()*m.scale)
+			to, ok2 := m.emu.Scr.AddrAt(args[2].Float()*m.scale, args[3].Float()*m.scale)
+			rect := args[4].Bool()
+			if !ok1 || !ok2 {
+				return ""
+			}
+			return m.emu.Cells.Copy(from, to, rect)
+		}),
+		"cursor": js.FuncOf(func(this js.Value, args []js.Value) any {
+			m.emu.Scr.ShowCursor(types.CursorStyleOf(args[0].String()), args[1].Bool(), args[2].Bool())
+			return nil
+		}),
+		"debug": js.FuncOf(func(this js.Value, args []js.Value) any {
+			m.emu.Dbg.Enable(args[0].Bool())
+			return nil
+		}),
+		"fields": js.FuncOf(func(this js.Value, args []js.Value) any {
+			flds := m.emu.Insp.Flds()
+			infos := make([]any, len(flds))
+			for ix, fld := range flds {
+				infos[ix] = m.fldInfo(fld)
+			}
+			return infos
+		}),
+		"fit": js.FuncOf(func(this js.Value, args []js.Value) any {
+			// 👇 width and height of the container, in CSS pixels
+			m.rescale()
+			fontSize := core.FitFontSize(m.emu.Cfg, args[0].Float()*m.scale, args[1].Float()*m.scale)
+			m.bus.PubResize(types.Geometry{FontSize: fontSize})
+			m.sizeCanvas(m.emu.Cfg)
+			return fontSize
+		}),
+		"font": js.FuncOf(func(this js.Value, args []js.Value) any {
+			// 👇 either the name of an embedded font, or TTF bytes
+			var family fonts.Family
+			if args[0].Type() == js.TypeString {
+				f, ok := fonts.FamilyOf(args[0].String())
+				if !ok {
+					return fmt.Sprintf("unknown font %s", args[0].String())
+				}
+				family = f
+			} else {
+				ttf := make([]byte, args[0].Get("length").Int())
+				js.CopyBytesToGo(ttf, args[0])
+				family = fonts.UserFamily("user", ttf)
+			}
+			// 🔥 make sure it can be parsed before we commit to it
+			if _, _, _, _, err := core.NewFaces(family, m.emu.Cfg.FontSize, m.emu.Cfg.DPI); err != nil {
+				return err.Error()
+			}
+			m.bus.PubResize(types.Geometry{Family: family})
+			m.sizeCanvas(m.emu.Cfg)
+			return ""
+		}),
 		"focus": js.FuncOf(func(this js.Value, args []js.Value) any {
 			state := args[0].Bool()
 			m.bus.PubFocus(state)
 			return nil
 		}),
+		"inspect": js.FuncOf(func(this js.Value, args []js.Value) any {
+			// 👇 x, y of the pointer, in CSS pixels, as on hover
+			addr, ok := m.emu.Scr.AddrAt(args[0].Float()*m.scale, args[1].Float()*m.scale)
+			if !ok {
+				return nil
+			}
+			return m.cellInfo(addr)
+		}),
 		"keystroke": js.FuncOf(func(this js.Value, args []js.Value) any {
 			key := types.Keystroke{
 				Code:  args[0].String(),
@@ -173,10 +250,135 @@ func (m *Mediator) jsInterface() js.Value {
 			}
 			return nil
 		}),
+		"overlay": js.FuncOf(func(this js.Value, args []js.Value) any {
+			// 👇 show the overlay, then optionally reveal non-display data
+			m.emu.Scr.ShowOverlay(args[0].Bool(), len(args) > 1 && args[1].Bool())
+			return nil
+		}),
+		"paste": js.FuncOf(func(this js.Value, args []js.Value) any {
+			m.bus.PubPaste(args[0].String())
+			return nil
+		}),
+		"pointer": js.FuncOf(func(this js.Value, args []js.Value) any {
+			ptr := types.Pointer{
+				Type:   args[0].String(),
+				X:      args[1].Float() * m.scale,
+				Y:      args[2].Float() * m.scale,
+				DeltaY: args[3].Float(),
+			}
+			m.bus.PubPointer(ptr)
+			return nil
+		}),
+		"profile": js.FuncOf(func(this js.Value, args []js.Value) any {
+			p, ok := types.ProfileOf(args[0].String())
+			if !ok {
+				return false
+			}
+			// 👇 optionally remap the 3279 base colors
+			//    [unprotected normal, unprotected intensified,
+			//     protected normal, protected intensified]
+			if len(args) > 1 && args[1].Truthy() {
+				p.BaseColors = types.BaseColors{
+					UnprotectedNormal:      types.Color(args[1].Index(0).Int()),
+					UnprotectedIntensified: types.Color(args[1].Index(1).Int()),
+					ProtectedNormal:        types.Color(args[1].Index(2).Int()),
+					ProtectedIntensified:   types.Color(args[1].Index(3).Int()),
+				}
+			}
+			m.bus.PubProfile(p)
+			return true
+		}),
+		"resize": js.FuncOf(func(this js.Value, args []js.Value) any {
+			geo := types.Geometry{
+				FontSize:     args[0].Float(),
+				DPI:          args[1].Float(),
+				PaddedHeight: args[2].Float(),
+				PaddedWidth:  args[3].Float(),
+			}
+			m.bus.PubResize(geo)
+			m.sizeCanvas(m.emu.Cfg)
+			return nil
+		}),
+		"step": js.FuncOf(func(this js.Value, args []js.Value) any {
+			step, ok := m.emu.Dbg.Step()
+			return m.step(step, ok)
+		}),
 	}
 	return js.ValueOf(functions)
 }
 
+// 👇 what the debugger just did, or null if there was nothing to do
+func (m *Mediator) step(step core.Step, ok bool) any {
+	if !ok {
+		return nil
+	}
+	row, col := m.emu.Cfg.Addr2RC(step.Addr)
+	touched := make([]any, len(step.Touched))
+	for ix, addr := range step.Touched {
+		touched[ix] = addr
+	}
+	return map[string]any{
+		"addr":     step.Addr,
+		"break":    step.Break,
+		"col":      col,
+		"done":     step.Done,
+		"fldAddr":  step.FldAddr,
+		"fldAttrs": step.FldAttrs.String(),
+		"inFld":    step.InFld,
+		"order":    step.Order.String(),
+		"row":      row,
+		"touched":  touched,
+	}
+}
+
+// 🟦 Translate inspector data for the UI
+
+func (m *Mediator) cellInfo(addr uint) any {
+	info, ok := m.emu.Insp.Cell(addr)
+	if !ok {
+		return nil
+	}
+	var fld any
+	var r string
+	if info.Rune != 0 {
+		r = string(info.Rune)
+	}
+	if info.Fld != nil {
+		fld = m.fldInfo(*info.Fld)
+	}
+	return map[string]any{
+		"addr":     info.Addr,
+		"attrs":    info.Attrs.String(),
+		"char":     int(info.Char),
+		"charAttr": info.Attrs.CharAttr,
+		"col":      info.Col,
+		"fld":      fld,
+		"fldHome":  info.FldHome,
+		"fldStart": info.FldStart,
+		"lcid":     int(info.Attrs.LCID),
+		"row":      info.Row,
+		"rune":     r,
+	}
+}
+
+func (m *Mediator) fldInfo(info core.FldInfo) map[string]any {
+	return map[string]any{
+		"addr":     info.Addr,
+		"attrs":    info.Attrs.String(),
+		"autoskip": info.Validation.Autoskip,
+		"col":      info.Col,
+		"end":      info.End,
+		"hasHome":  info.HasHome,
+		"home":     info.Home,
+		"input":    info.Validation.Input,
+		"len":      info.Len,
+		"mdt":      info.MDT,
+		"numeric":  info.Validation.Numeric,
+		"row":      info.Row,
+		"value":    info.Value,
+	}
+}
+
 // 🟦 Forward messages from subscriptions to UI via dispatchEvent
 
 func (m *Mediator) dispatchEvent(params map[string]any) {
@@ -206,10 +408,20 @@ func (m *Mediator) panic(msg string) {
 	m.bus.UnsubscribeAll()
 }
 
+// 👇 Ctrl+Arrow probes a cell, which a devtools panel may show
+func (m *Mediator) probe(addr uint) {
+	params := map[string]any{
+		"eventType": "probe",
+		"cell":      m.cellInfo(addr),
+	}
+	m.dispatchEvent(params)
+}
+
 func (m *Mediator) status(stat *types.Status) {
 	params := map[string]any{
 		"eventType": "status",
 		"alarm":     stat.Alarm,
+		"connected": stat.Connected,
 		"cursorAt":  stat.CursorAt,
 		"error":     stat.Error,
 		"insert":    stat.Insert,
@@ -224,34 +436,38 @@ func (m *Mediator) status(stat *types.Status) {
 
 // 🟦 Render drawing context when changed via requestAnimationFrame
 
-func (m *Mediator) rcLoop(canvas js.Value, rgba *image.RGBA, maxFPS float64) {
+func (m *Mediator) rcLoop(maxFPS float64) {
 	var (
-		lastImage     []byte
 		lastTimestamp float64
 		rc            js.Func
 	)
 	rc = js.FuncOf(func(this js.Value, args []js.Value) any {
 		timestamp := args[0].Float()
 		// 👇 make sure we don't bust the max FPS we were given
-		if timestamp-lastTimestamp >= (1000 / maxFPS) {
-			if lastImage == nil || !bytes.Equal(lastImage, rgba.Pix) {
-				// 🔥 I copied this from go-canvas where the author was worried
-				// about 3 separate copies -- I haven't figured how to reduce
-				// it to 2 even when using Uint8ClampedArray --
-				// but it only takes ~2ms anyway
-				pixels := js.Global().Get("Uint8ClampedArray").New(len(rgba.Pix))
-				js.CopyBytesToJS(pixels, rgba.Pix)
-				canvasHeight := canvas.Get("offsetHeight")
-				canvasWidth := canvas.Get("offsetWidth")
-				ctx := canvas.Call("getContext", "2d")
-				img := ctx.Call("createImageData", canvasWidth, canvasHeight)
+		// 🔥 the emulator is always created before the first frame
+		if timestamp-lastTimestamp >= (1000/maxFPS) && m.emu != nil {
+			// 👇 only copy what the screen has actually drawn
+			rgba := m.emu.Cfg.RGBA
+			ctx := m.canvas.Call("getContext", "2d")
+			for _, rect := range m.emu.Scr.Damage() {
+				rect = rect.Intersect(rgba.Bounds())
+				if rect.Empty() {
+					continue
+				}
+				// 👇 gather the rows of the region into one contiguous copy
+				w, h := rect.Dx(), rect.Dy()
+				pix := make([]byte, 0, w*h*4)
+				for y := rect.Min.Y; y < rect.Max.Y; y++ {
+					ix := rgba.PixOffset(rect.Min.X, y)
+					pix = append(pix, rgba.Pix[ix:ix+w*4]...)
+				}
+				pixels := js.Global().Get("Uint8ClampedArray").New(len(pix))
+				js.CopyBytesToJS(pixels, pix)
+				img := ctx.Call("createImageData", w, h)
 				img.Get("data").Call("set", pixels)
-				ctx.Call("putImageData", img, 0, 0)
-				// 👇 set up for next time
-				lastImage = make([]byte, len(rgba.Pix))
-				copy(lastImage, rgba.Pix)
-				lastTimestamp = timestamp
+				ctx.Call("putImageData", img, rect.Min.X, rect.Min.Y)
 			}
+			lastTimestamp = timestamp
 		}
 		js.Global().Call("requestAnimationFrame", rc)
 		return nil
@@ -260,6 +476,28 @@ func (m *Mediator) rcLoop(canvas js.Value, rgba *image.RGBA, maxFPS float64) {
 	js.Global().Call("requestAnimationFrame", rc)
 }
 
+// 🟦 Size the canvas to match the rendering surface
+
+// 🔥 the UI's DPI includes the device pixel ratio, so on HiDPI
+//    screens the canvas is scaled down to its CSS size -- and the
+//    ratio changes as the browser zooms or moves between screens
+
+func (m *Mediator) rescale() {
+	m.scale = max(js.Global().Get("devicePixelRatio").Float(), 1)
+}
+
+func (m *Mediator) sizeCanvas(cfg *types.Config) {
+	m.rescale()
+	rect := cfg.RGBA.Bounds()
+	wrapper := m.canvas.Get("parentNode")
+	wrapper.Get("style").Set("width", fmt.Sprintf("%fpx", float64(rect.Dx())/m.scale))
+	wrapper.Get("style").Set("height", fmt.Sprintf("%fpx", float64(rect.Dy())/m.scale))
+	m.canvas.Get("style").Set("width", fmt.Sprintf("%fpx", float64(rect.Dx())/m.scale))
+	m.canvas.Get("style").Set("height", fmt.Sprintf("%fpx", float64(rect.Dy())/m.scale))
+	m.canvas.Set("width", rect.Dx())
+	m.canvas.Set("height", rect.Dy())
+}
+
 // 🟦 Inject ticks into the system eg: to support blinking
 
 func (m *Mediator) tickLoop(interval int) {
@@ -0,0 +1,160 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"net/netip"
+)
+
+// 🟧 Decode a captured frame down to its TCP segment, if it has one
+
+type Segment struct {
+	Dst     netip.AddrPort
+	FIN     bool
+	Payload []byte
+	RST     bool
+	Seq     uint32
+	Src     netip.AddrPort
+	SYN     bool
+}
+
+// 🟦 Lookup tables
+
+// 👁️ https://www.tcpdump.org/linktypes.html
+
+const (
+	LINKTYPE_NULL     uint16 = 0
+	LINKTYPE_ETHERNET uint16 = 1
+	LINKTYPE_RAW      uint16 = 101
+	LINKTYPE_LOOP     uint16 = 108
+	LINKTYPE_SLL      uint16 = 113
+	LINKTYPE_IPV4     uint16 = 228
+	LINKTYPE_IPV6     uint16 = 229
+	LINKTYPE_SLL2     uint16 = 276
+)
+
+const (
+	ETHERTYPE_IPV4 uint16 = 0x0800
+	ETHERTYPE_IPV6 uint16 = 0x86dd
+	ETHERTYPE_QINQ uint16 = 0x88a8
+	ETHERTYPE_VLAN uint16 = 0x8100
+)
+
+const PROTO_TCP byte = 6
+
+// 🟦 Public functions
+
+// 👇 fragments and anything that isn't TCP over IP are ignored
+func (p Packet) Segment() (Segment, bool) {
+	ip, ok := p.ip()
+	if !ok || len(ip) < 1 {
+		return Segment{}, false
+	}
+	switch ip[0] >> 4 {
+
+	case 4:
+		return ipv4(ip)
+
+	case 6:
+		return ipv6(ip)
+
+	}
+	return Segment{}, false
+}
+
+// 🟦 Helpers
+
+// 👇 strip the link layer, leaving the IP datagram
+func (p Packet) ip() ([]byte, bool) {
+	data := p.Data
+	switch p.LinkType {
+
+	case LINKTYPE_ETHERNET:
+		if len(data) < 14 {
+			return nil, false
+		}
+		etherType := binary.BigEndian.Uint16(data[12:])
+		data = data[14:]
+		for (etherType == ETHERTYPE_VLAN || etherType == ETHERTYPE_QINQ) && len(data) >= 4 {
+			etherType = binary.BigEndian.Uint16(data[2:])
+			data = data[4:]
+		}
+		return data, etherType == ETHERTYPE_IPV4 || etherType == ETHERTYPE_IPV6
+
+	// 👇 a 4-byte address family, which the IP version tells us anyway
+	case LINKTYPE_NULL, LINKTYPE_LOOP:
+		return data[min(4, len(data)):], len(data) > 4
+
+	case LINKTYPE_RAW, LINKTYPE_IPV4, LINKTYPE_IPV6:
+		return data, true
+
+	case LINKTYPE_SLL:
+		return data[min(16, len(data)):], len(data) > 16
+
+	case LINKTYPE_SLL2:
+		return data[min(20, len(data)):], len(data) > 20
+
+	}
+	return nil, false
+}
+
+func ipv4(ip []byte) (Segment, bool) {
+	if len(ip) < 20 {
+		return Segment{}, false
+	}
+	ihl := int(ip[0]&0x0f) * 4
+	total := int(binary.BigEndian.Uint16(ip[2:]))
+	// 👇 more fragments, or a fragment offset
+	fragmented := binary.BigEndian.Uint16(ip[6:])&0x3fff != 0
+	if ip[9] != PROTO_TCP || fragmented || ihl < 20 || total < ihl || total > len(ip) {
+		return Segment{}, false
+	}
+	src, _ := netip.AddrFromSlice(ip[12:16])
+	dst, _ := netip.AddrFromSlice(ip[16:20])
+	// 🔥 Ethernet pads short frames, so trust the IP length
+	return tcp(src, dst, ip[ihl:total])
+}
+
+func ipv6(ip []byte) (Segment, bool) {
+	if len(ip) < 40 {
+		return Segment{}, false
+	}
+	total := 40 + int(binary.BigEndian.Uint16(ip[4:]))
+	if total > len(ip) {
+		return Segment{}, false
+	}
+	src, _ := netip.AddrFromSlice(ip[8:24])
+	dst, _ := netip.AddrFromSlice(ip[24:40])
+	next, payload := ip[6], ip[40:total]
+	// 👇 skip hop-by-hop, routing and destination options headers
+	for (next == 0 || next == 43 || next == 60) && len(payload) >= 8 {
+		length := (int(payload[1]) + 1) * 8
+		if length > len(payload) {
+			return Segment{}, false
+		}
+		next, payload = payload[0], payload[length:]
+	}
+	if next != PROTO_TCP {
+		return Segment{}, false
+	}
+	return tcp(src, dst, payload)
+}
+
+func tcp(src, dst netip.Addr, data []byte) (Segment, bool) {
+	if len(data) < 20 {
+		return Segment{}, false
+	}
+	offset := int(data[12]>>4) * 4
+	if offset < 20 || offset > len(data) {
+		return Segment{}, false
+	}
+	flags := data[13]
+	return Segment{
+		Dst:     netip.AddrPortFrom(dst, binary.BigEndian.Uint16(data[2:])),
+		FIN:     flags&0x01 != 0,
+		Payload: data[offset:],
+		RST:     flags&0x04 != 0,
+		Seq:     binary.BigEndian.Uint32(data[4:]),
+		Src:     netip.AddrPortFrom(src, binary.BigEndian.Uint16(data[0:])),
+		SYN:     flags&0x02 != 0,
+	}, true
+}
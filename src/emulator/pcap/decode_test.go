@@ -0,0 +1,96 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fin byte = 0x01
+	syn byte = 0x02
+	ack byte = 0x10
+)
+
+// 👇 a TCP segment in an IPv4 datagram, addresses as "1.2.3.4:5"
+func mockIPv4(src, dst string, seq uint32, flags byte, payload []byte) []byte {
+	s, d := netip.MustParseAddrPort(src), netip.MustParseAddrPort(dst)
+	seg := make([]byte, 20, 20+len(payload))
+	binary.BigEndian.PutUint16(seg[0:], s.Port())
+	binary.BigEndian.PutUint16(seg[2:], d.Port())
+	binary.BigEndian.PutUint32(seg[4:], seq)
+	seg[12] = 5 << 4
+	seg[13] = flags
+	seg = append(seg, payload...)
+	ip := make([]byte, 20, 20+len(seg))
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(20+len(seg)))
+	ip[9] = PROTO_TCP
+	copy(ip[12:], s.Addr().AsSlice())
+	copy(ip[16:], d.Addr().AsSlice())
+	return append(ip, seg...)
+}
+
+func mockEthernet(ip []byte) []byte {
+	frame := make([]byte, 14, 14+len(ip))
+	binary.BigEndian.PutUint16(frame[12:], ETHERTYPE_IPV4)
+	return append(frame, ip...)
+}
+
+func TestSegment(t *testing.T) {
+	ip := mockIPv4("10.0.0.1:40000", "10.0.0.2:3270", 1000, ack, []byte("hi"))
+
+	t.Run("Ethernet, with padding", func(t *testing.T) {
+		frame := append(mockEthernet(ip), 0, 0, 0, 0)
+		seg, ok := Packet{Data: frame, LinkType: LINKTYPE_ETHERNET}.Segment()
+		assert.True(t, ok)
+		assert.Equal(t, "10.0.0.1:40000", seg.Src.String())
+		assert.Equal(t, uint16(3270), seg.Dst.Port())
+		assert.Equal(t, uint32(1000), seg.Seq)
+		assert.Equal(t, []byte("hi"), seg.Payload)
+	})
+
+	t.Run("VLAN tagged", func(t *testing.T) {
+		frame := make([]byte, 18)
+		binary.BigEndian.PutUint16(frame[12:], ETHERTYPE_VLAN)
+		binary.BigEndian.PutUint16(frame[16:], ETHERTYPE_IPV4)
+		seg, ok := Packet{Data: append(frame, ip...), LinkType: LINKTYPE_ETHERNET}.Segment()
+		assert.True(t, ok)
+		assert.Equal(t, []byte("hi"), seg.Payload)
+	})
+
+	t.Run("loopback and raw IP", func(t *testing.T) {
+		_, ok := Packet{Data: append([]byte{2, 0, 0, 0}, ip...), LinkType: LINKTYPE_NULL}.Segment()
+		assert.True(t, ok)
+		_, ok = Packet{Data: ip, LinkType: LINKTYPE_RAW}.Segment()
+		assert.True(t, ok)
+	})
+
+	t.Run("IPv6", func(t *testing.T) {
+		tcp := ip[20:]
+		ip6 := make([]byte, 40)
+		ip6[0] = 0x60
+		binary.BigEndian.PutUint16(ip6[4:], uint16(len(tcp)))
+		ip6[6] = PROTO_TCP
+		copy(ip6[8:], netip.MustParseAddr("::1").AsSlice())
+		copy(ip6[24:], netip.MustParseAddr("::2").AsSlice())
+		seg, ok := Packet{Data: append(ip6, tcp...), LinkType: LINKTYPE_RAW}.Segment()
+		assert.True(t, ok)
+		assert.Equal(t, "[::2]:3270", seg.Dst.String())
+	})
+
+	t.Run("not TCP, or a fragment", func(t *testing.T) {
+		udp := append([]byte(nil), ip...)
+		udp[9] = 17
+		_, ok := Packet{Data: udp, LinkType: LINKTYPE_RAW}.Segment()
+		assert.False(t, ok)
+		frag := append([]byte(nil), ip...)
+		frag[6] = 0x20
+		_, ok = Packet{Data: frag, LinkType: LINKTYPE_RAW}.Segment()
+		assert.False(t, ok)
+		_, ok = Packet{Data: ip[:10], LinkType: LINKTYPE_RAW}.Segment()
+		assert.False(t, ok)
+	})
+}
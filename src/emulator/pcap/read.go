@@ -0,0 +1,244 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// 🟧 Read the packets from a pcap or pcapng capture, as saved by
+//    Wireshark or tcpdump
+
+// 👁️ https://www.ietf.org/archive/id/draft-ietf-opsawg-pcap-03.html
+// 👁️ https://www.ietf.org/archive/id/draft-ietf-opsawg-pcapng-01.html
+
+type Packet struct {
+	At       time.Time
+	Data     []byte
+	LinkType uint16
+}
+
+// 🟦 Lookup tables
+
+const (
+	PCAP_MICROS uint32 = 0xa1b2c3d4
+	PCAP_NANOS  uint32 = 0xa1b23c4d
+	PCAPNG_SHB  uint32 = 0x0a0d0d0a
+)
+
+const (
+	BLOCK_EPB uint32 = 6
+	BLOCK_IDB uint32 = 1
+	BLOCK_SPB uint32 = 3
+)
+
+const (
+	BYTE_ORDER_MAGIC uint32 = 0x1a2b3c4d
+	OPT_TSRESOL      uint16 = 9
+)
+
+// 🟦 Constructor
+
+// 👇 every packet in the capture, whichever format it is
+func Read(r io.Reader) ([]Packet, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, fmt.Errorf("pcap: %w", err)
+	}
+	switch {
+
+	case binary.BigEndian.Uint32(magic[:]) == PCAPNG_SHB:
+		return readPcapng(io.MultiReader(bytes.NewReader(magic[:]), r))
+
+	case isPcap(binary.LittleEndian, magic[:]):
+		return readPcap(binary.LittleEndian, magic[:], r)
+
+	case isPcap(binary.BigEndian, magic[:]):
+		return readPcap(binary.BigEndian, magic[:], r)
+
+	}
+	return nil, fmt.Errorf("pcap: not a pcap or pcapng file")
+}
+
+// 🟦 pcap
+
+func isPcap(order binary.ByteOrder, magic []byte) bool {
+	m := order.Uint32(magic)
+	return m == PCAP_MICROS || m == PCAP_NANOS
+}
+
+func readPcap(order binary.ByteOrder, magic []byte, r io.Reader) ([]Packet, error) {
+	nanos := order.Uint32(magic) == PCAP_NANOS
+	// 👇 the rest of the file header
+	var hdr [20]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, fmt.Errorf("pcap: header: %w", err)
+	}
+	linkType := uint16(order.Uint32(hdr[16:]))
+	packets := make([]Packet, 0)
+	for {
+		var rec [16]byte
+		_, err := io.ReadFull(r, rec[:])
+		if errors.Is(err, io.EOF) {
+			return packets, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pcap: packet %d: %w", len(packets)+1, err)
+		}
+		secs, frac := order.Uint32(rec[0:]), order.Uint32(rec[4:])
+		data := make([]byte, order.Uint32(rec[8:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("pcap: packet %d: %w", len(packets)+1, err)
+		}
+		at := time.Unix(int64(secs), int64(frac)*int64(time.Microsecond))
+		if nanos {
+			at = time.Unix(int64(secs), int64(frac))
+		}
+		packets = append(packets, Packet{At: at, Data: data, LinkType: linkType})
+	}
+}
+
+// 🟦 pcapng
+
+// 👇 what we need to know about each interface
+type iface struct {
+	linkType uint16
+	// 👇 timestamp units per second
+	resolution float64
+}
+
+func readPcapng(r io.Reader) ([]Packet, error) {
+	var order binary.ByteOrder = binary.LittleEndian
+	ifaces := make([]iface, 0)
+	packets := make([]Packet, 0)
+	for {
+		var head [8]byte
+		_, err := io.ReadFull(r, head[:])
+		if errors.Is(err, io.EOF) {
+			return packets, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pcapng: %w", err)
+		}
+		// 👇 each section declares its own byte order
+		if binary.BigEndian.Uint32(head[:]) == PCAPNG_SHB {
+			var bom [4]byte
+			if _, err := io.ReadFull(r, bom[:]); err != nil {
+				return nil, fmt.Errorf("pcapng: %w", err)
+			}
+			order = orderOf(bom[:])
+			if order == nil {
+				return nil, fmt.Errorf("pcapng: bad byte order magic")
+			}
+			// 👇 a new section starts a new set of interfaces
+			ifaces = ifaces[:0]
+			if err := skip(r, int(order.Uint32(head[4:]))-12); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		kind, length := order.Uint32(head[:]), int(order.Uint32(head[4:]))
+		if length < 12 || length%4 != 0 {
+			return nil, fmt.Errorf("pcapng: bad block length %d", length)
+		}
+		body := make([]byte, length-8)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, fmt.Errorf("pcapng: %w", err)
+		}
+		// 👇 drop the trailing copy of the length
+		body = body[:len(body)-4]
+		switch kind {
+
+		case BLOCK_IDB:
+			if len(body) < 8 {
+				return nil, fmt.Errorf("pcapng: short interface block")
+			}
+			ifaces = append(ifaces, iface{
+				linkType:   order.Uint16(body[0:]),
+				resolution: resolutionOf(order, body[8:]),
+			})
+
+		case BLOCK_EPB:
+			if len(body) < 20 {
+				return nil, fmt.Errorf("pcapng: short packet block")
+			}
+			ix := order.Uint32(body[0:])
+			if int(ix) >= len(ifaces) {
+				return nil, fmt.Errorf("pcapng: no interface %d", ix)
+			}
+			ts := uint64(order.Uint32(body[4:]))<<32 | uint64(order.Uint32(body[8:]))
+			caplen := int(order.Uint32(body[12:]))
+			if 20+caplen > len(body) {
+				return nil, fmt.Errorf("pcapng: packet overruns its block")
+			}
+			packets = append(packets, Packet{
+				At:       timeOf(ts, ifaces[ix].resolution),
+				Data:     body[20 : 20+caplen],
+				LinkType: ifaces[ix].linkType,
+			})
+
+		case BLOCK_SPB:
+			// 🔥 simple packets have no timestamp, and belong to the
+			//    first interface
+			if len(ifaces) == 0 || len(body) < 4 {
+				return nil, fmt.Errorf("pcapng: simple packet without interface")
+			}
+			caplen := min(int(order.Uint32(body[0:])), len(body)-4)
+			packets = append(packets, Packet{Data: body[4 : 4+caplen], LinkType: ifaces[0].linkType})
+
+		}
+	}
+}
+
+// 🟦 Helpers
+
+func orderOf(bom []byte) binary.ByteOrder {
+	switch {
+
+	case binary.LittleEndian.Uint32(bom) == BYTE_ORDER_MAGIC:
+		return binary.LittleEndian
+
+	case binary.BigEndian.Uint32(bom) == BYTE_ORDER_MAGIC:
+		return binary.BigEndian
+
+	}
+	return nil
+}
+
+// 👇 the if_tsresol option, if any, else microseconds
+func resolutionOf(order binary.ByteOrder, opts []byte) float64 {
+	for len(opts) >= 4 {
+		code, length := order.Uint16(opts[0:]), int(order.Uint16(opts[2:]))
+		if code == 0 || 4+length > len(opts) {
+			break
+		}
+		if code == OPT_TSRESOL && length >= 1 {
+			res := opts[4]
+			// 👇 high bit set means a power of 2, else of 10
+			if res&0x80 != 0 {
+				return math.Pow(2, float64(res&0x7f))
+			}
+			return math.Pow(10, float64(res))
+		}
+		opts = opts[4+(length+3)/4*4:]
+	}
+	return 1e6
+}
+
+func skip(r io.Reader, n int) error {
+	if n < 0 {
+		return fmt.Errorf("pcapng: bad block length")
+	}
+	_, err := io.CopyN(io.Discard, r, int64(n))
+	return err
+}
+
+func timeOf(ts uint64, resolution float64) time.Time {
+	secs := ts / uint64(resolution)
+	frac := float64(ts%uint64(resolution)) / resolution
+	return time.Unix(int64(secs), int64(frac*1e9))
+}
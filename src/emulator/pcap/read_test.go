@@ -0,0 +1,79 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 a classic pcap file of Ethernet frames, one a second
+func mockPcap(order binary.ByteOrder, magic uint32, frames ...[]byte) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, order, []uint32{magic, 0x00040002, 0, 0, 65535, uint32(LINKTYPE_ETHERNET)})
+	for ix, frame := range frames {
+		binary.Write(&b, order, []uint32{uint32(1000 + ix), 500, uint32(len(frame)), uint32(len(frame))})
+		b.Write(frame)
+	}
+	return b.Bytes()
+}
+
+// 👇 a pcapng file of Ethernet frames, with nanosecond timestamps
+func mockPcapng(frames ...[]byte) []byte {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	binary.Write(&b, binary.BigEndian, PCAPNG_SHB)
+	binary.Write(&b, le, []uint32{28, BYTE_ORDER_MAGIC, 1, 0xffffffff, 0xffffffff, 28})
+	// 👇 interface with if_tsresol = 9
+	binary.Write(&b, le, []uint32{BLOCK_IDB, 32, uint32(LINKTYPE_ETHERNET), 65535})
+	binary.Write(&b, le, []uint16{OPT_TSRESOL, 1})
+	b.Write([]byte{9, 0, 0, 0})
+	binary.Write(&b, le, []uint32{0, 32})
+	for ix, frame := range frames {
+		padded := append(frame, make([]byte, (4-len(frame)%4)%4)...)
+		length := uint32(32 + len(padded))
+		ts := uint64(1000+ix)*1e9 + 500
+		binary.Write(&b, le, []uint32{BLOCK_EPB, length, 0, uint32(ts >> 32), uint32(ts), uint32(len(frame)), uint32(len(frame))})
+		b.Write(padded)
+		binary.Write(&b, le, length)
+	}
+	return b.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	frame := mockEthernet(mockIPv4("10.0.0.1:40000", "10.0.0.2:3270", 1, ack, []byte("hi")))
+
+	t.Run("pcap, either byte order", func(t *testing.T) {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			packets, err := Read(bytes.NewReader(mockPcap(order, PCAP_MICROS, frame, frame)))
+			assert.NoError(t, err)
+			assert.Len(t, packets, 2)
+			assert.Equal(t, frame, packets[0].Data)
+			assert.Equal(t, LINKTYPE_ETHERNET, packets[0].LinkType)
+			assert.Equal(t, time.Unix(1001, 500*1000), packets[1].At)
+		}
+	})
+
+	t.Run("pcap with nanoseconds", func(t *testing.T) {
+		packets, err := Read(bytes.NewReader(mockPcap(binary.LittleEndian, PCAP_NANOS, frame)))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Unix(1000, 500), packets[0].At)
+	})
+
+	t.Run("pcapng", func(t *testing.T) {
+		packets, err := Read(bytes.NewReader(mockPcapng(frame, frame)))
+		assert.NoError(t, err)
+		assert.Len(t, packets, 2)
+		assert.Equal(t, frame, packets[0].Data)
+		assert.Equal(t, time.Unix(1001, 500), packets[1].At)
+	})
+
+	t.Run("neither", func(t *testing.T) {
+		_, err := Read(bytes.NewReader([]byte("GIF89a")))
+		assert.ErrorContains(t, err, "not a pcap")
+		_, err = Read(bytes.NewReader(mockPcap(binary.LittleEndian, PCAP_MICROS, frame)[:40]))
+		assert.ErrorContains(t, err, "packet 1")
+	})
+}
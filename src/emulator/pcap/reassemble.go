@@ -0,0 +1,137 @@
+package pcap
+
+import (
+	"emulator/trace"
+	"emulator/utils"
+	"fmt"
+	"io"
+	"net/netip"
+)
+
+// 🟧 Reassemble each TCP connection to a 3270 port and turn it into
+//    a trace, one record per event
+
+// 👇 each connection is known by its client and server
+
+type connKey struct {
+	client netip.AddrPort
+	server netip.AddrPort
+}
+
+type conn struct {
+	// 👇 from the host, then to it
+	flows  [2]*flow
+	start  Packet
+	telnet *trace.Telnet
+}
+
+// 👇 one direction of a connection, in sequence order
+
+type flow struct {
+	next    uint32
+	pending map[uint32][]byte
+	started bool
+}
+
+// 🟦 Constructor
+
+// 👇 every connection to the port that carried 3270 data, in order,
+//    where port 0 takes the lower port of each connection as the host
+
+func Import(r io.Reader, port uint16) ([]*trace.Trace, error) {
+	packets, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	conns := make([]*conn, 0)
+	live := make(map[connKey]*conn)
+	for _, p := range packets {
+		seg, ok := p.Segment()
+		if !ok || (port != 0 && seg.Src.Port() != port && seg.Dst.Port() != port) {
+			continue
+		}
+		fromHost := utils.Ternary(port == 0, seg.Src.Port() < seg.Dst.Port(), seg.Src.Port() == port)
+		key := connKey{client: seg.Dst, server: seg.Src}
+		if !fromHost {
+			key = connKey{client: seg.Src, server: seg.Dst}
+		}
+		c, ok := live[key]
+		// 👇 a client's SYN always starts a new connection
+		if !ok || (seg.SYN && !fromHost && c.flows[1].started) {
+			c = newConn(p)
+			conns = append(conns, c)
+			live[key] = c
+		}
+		kind := trace.INBOUND
+		ix := 1
+		if fromHost {
+			kind = trace.OUTBOUND
+			ix = 0
+		}
+		if chars := c.flows[ix].add(seg); len(chars) > 0 {
+			c.telnet.Feed(kind, p.At.Sub(c.start.At), chars)
+		}
+	}
+	traces := make([]*trace.Trace, 0)
+	for _, c := range conns {
+		if t := c.telnet.Trace(); len(t.Events) > 0 {
+			traces = append(traces, t)
+		}
+	}
+	if len(traces) == 0 {
+		return nil, fmt.Errorf("pcap: no 3270 data%s", utils.Ternary(port == 0, "", fmt.Sprintf(" on port %d", port)))
+	}
+	return traces, nil
+}
+
+func newConn(start Packet) *conn {
+	c := new(conn)
+	c.flows = [2]*flow{newFlow(), newFlow()}
+	c.start = start
+	c.telnet = trace.NewTelnet(trace.SOURCE_PCAP)
+	c.telnet.Trace().Header.Started = start.At
+	return c
+}
+
+func newFlow() *flow {
+	f := new(flow)
+	f.pending = make(map[uint32][]byte)
+	return f
+}
+
+// 🟦 Helpers
+
+// 👇 whatever is now contiguous, holding back early arrivals
+func (f *flow) add(seg Segment) []byte {
+	if seg.SYN {
+		f.next = seg.Seq + 1
+		f.started = true
+		return nil
+	}
+	if !f.started {
+		f.next = seg.Seq
+		f.started = true
+	}
+	if len(seg.Payload) == 0 {
+		return nil
+	}
+	f.pending[seg.Seq] = seg.Payload
+	var chars []byte
+	for progress := true; progress; {
+		progress = false
+		for seq, payload := range f.pending {
+			// 🔥 sequence numbers wrap, so compare the difference
+			ahead := int32(seq - f.next)
+			if ahead > 0 {
+				continue
+			}
+			delete(f.pending, seq)
+			if overlap := int(-ahead); overlap < len(payload) {
+				chars = append(chars, payload[overlap:]...)
+				f.next += uint32(len(payload) - overlap)
+			}
+			progress = true
+		}
+	}
+	return chars
+}
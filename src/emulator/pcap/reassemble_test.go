@@ -0,0 +1,67 @@
+package pcap
+
+import (
+	"bytes"
+	"emulator/trace"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	client, host := "10.0.0.1:40000", "10.0.0.2:3270"
+	frames := [][]byte{
+		mockIPv4(client, host, 99, syn, nil),
+		mockIPv4(host, client, 499, syn|ack, nil),
+		// 👇 negotiation, then a record split in two, out of order
+		mockIPv4(host, client, 500, ack, []byte{0xff, 0xfd, 0x19}),
+		mockIPv4(host, client, 506, ack, []byte{0xff, 0xff, 0xff, 0xef}),
+		mockIPv4(host, client, 503, ack, []byte{0xf5, 0xc2, 0xc1}),
+		// 👇 a retransmission
+		mockIPv4(host, client, 503, ack, []byte{0xf5, 0xc2, 0xc1}),
+		mockIPv4(client, host, 100, ack, []byte{0xff, 0xfb, 0x19, 0x7d, 0xff, 0xef}),
+		// 👇 a second connection, and some other traffic
+		mockIPv4("10.0.0.1:40001", host, 7, syn, nil),
+		mockIPv4(host, "10.0.0.1:40001", 1000, ack, []byte{0xf1, 0xc2, 0xff, 0xef}),
+		mockIPv4(client, "10.0.0.2:80", 1, ack, []byte("GET / HTTP/1.1")),
+	}
+	for ix := range frames {
+		frames[ix] = mockEthernet(frames[ix])
+	}
+	traces, err := Import(bytes.NewReader(mockPcap(binary.LittleEndian, PCAP_MICROS, frames...)), 3270)
+	assert.NoError(t, err)
+	assert.Len(t, traces, 2)
+
+	t.Run("one record per event", func(t *testing.T) {
+		tr := traces[0]
+		assert.Equal(t, trace.SOURCE_PCAP, tr.Header.Source)
+		assert.Len(t, tr.Events, 2)
+		assert.Equal(t, trace.Event{At: 4e9, Data: trace.Hex{0xf5, 0xc2, 0xc1, 0xff}, Kind: trace.OUTBOUND}, tr.Events[0])
+		assert.Equal(t, trace.Hex{0x7d}, tr.Events[1].Data)
+		assert.Equal(t, trace.INBOUND, tr.Events[1].Kind)
+	})
+
+	t.Run("connections are kept apart", func(t *testing.T) {
+		assert.Equal(t, trace.Hex{0xf1, 0xc2}, traces[1].Events[0].Data)
+	})
+
+	t.Run("any port, taking the lower as the host", func(t *testing.T) {
+		traces, err := Import(bytes.NewReader(mockPcap(binary.LittleEndian, PCAP_MICROS, frames...)), 0)
+		assert.NoError(t, err)
+		assert.Len(t, traces, 2)
+		assert.Equal(t, trace.OUTBOUND, traces[0].Events[0].Kind)
+	})
+
+	t.Run("nothing on the port", func(t *testing.T) {
+		_, err := Import(bytes.NewReader(mockPcap(binary.LittleEndian, PCAP_MICROS, frames[0])), 23)
+		assert.ErrorContains(t, err, "port 23")
+	})
+}
+
+func TestFlowWraps(t *testing.T) {
+	f := newFlow()
+	f.add(Segment{Seq: 0xfffffffe, SYN: true})
+	assert.Equal(t, []byte("ab"), f.add(Segment{Seq: 0xffffffff, Payload: []byte("ab")}))
+	assert.Equal(t, []byte("cd"), f.add(Segment{Seq: 0, Payload: []byte("bcd")}))
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"emulator/session"
+	"emulator/types"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 🟧 Request and response bodies, and the handlers that use them
+
+type ConnectRequest struct {
+	Addr    string `json:"addr"`
+	Model   string `json:"model"`
+	Profile string `json:"profile"`
+	Render  bool   `json:"render"`
+}
+
+type CursorRequest struct {
+	Col uint `json:"col"`
+	Row uint `json:"row"`
+}
+
+// 👇 by label, or else by position
+
+type FillRequest struct {
+	Col   uint   `json:"col"`
+	Label string `json:"label"`
+	Row   uint   `json:"row"`
+	Text  string `json:"text"`
+}
+
+type PressRequest struct {
+	AID string `json:"aid"`
+}
+
+type TypeRequest struct {
+	Text string `json:"text"`
+}
+
+// 👇 for is one of cursor, input, output, text or unlock, and
+//    timeout is a Go duration, like "5s"
+
+type WaitRequest struct {
+	Col     uint   `json:"col"`
+	For     string `json:"for"`
+	Row     uint   `json:"row"`
+	Text    string `json:"text"`
+	Timeout string `json:"timeout"`
+}
+
+type Field struct {
+	Col       uint   `json:"col"`
+	Hidden    bool   `json:"hidden"`
+	Len       uint   `json:"len"`
+	Modified  bool   `json:"modified"`
+	Numeric   bool   `json:"numeric"`
+	Protected bool   `json:"protected"`
+	Row       uint   `json:"row"`
+	Text      string `json:"text"`
+}
+
+type Position struct {
+	Col uint `json:"col"`
+	Row uint `json:"row"`
+}
+
+type Screen struct {
+	Cols   uint     `json:"cols"`
+	Cursor Position `json:"cursor"`
+	Fields []Field  `json:"fields"`
+	Rows   uint     `json:"rows"`
+	Status Status   `json:"status"`
+	Text   []string `json:"text"`
+}
+
+type SessionInfo struct {
+	Addr  string `json:"addr"`
+	Error string `json:"error,omitempty"`
+	ID    string `json:"id"`
+}
+
+type Status struct {
+	Connected bool   `json:"connected"`
+	Error     bool   `json:"error"`
+	Insert    bool   `json:"insert"`
+	Locked    bool   `json:"locked"`
+	Message   string `json:"message"`
+	Numeric   bool   `json:"numeric"`
+	Protected bool   `json:"protected"`
+	Waiting   bool   `json:"waiting"`
+}
+
+// 🟦 Sessions
+
+func (s *Server) connect(w http.ResponseWriter, r *http.Request) {
+	var req ConnectRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Addr == "" {
+		reply(w, http.StatusBadRequest, Error{"bad request: addr is required"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	sess, err := s.dial(ctx, req.Addr, session.Options{Model: req.Model, Profile: req.Profile, Render: req.Render})
+	if err != nil {
+		reply(w, http.StatusBadGateway, Error{err.Error()})
+		return
+	}
+	id := s.add(req.Addr, sess)
+	reply(w, http.StatusCreated, SessionInfo{Addr: req.Addr, ID: id})
+}
+
+func (s *Server) disconnect(w http.ResponseWriter, r *http.Request) {
+	e, ok := s.remove(r.PathValue("id"))
+	if !ok {
+		reply(w, http.StatusNotFound, Error{fmt.Sprintf("no session %s", r.PathValue("id"))})
+		return
+	}
+	e.sess.Close()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) list(w http.ResponseWriter, _ *http.Request) {
+	infos := make([]SessionInfo, 0)
+	for _, id := range s.ids() {
+		s.mu.Lock()
+		e, ok := s.entries[id]
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+		info := SessionInfo{Addr: e.addr, ID: id}
+		if err := e.sess.Err(); err != nil {
+			info.Error = err.Error()
+		}
+		infos = append(infos, info)
+	}
+	reply(w, http.StatusOK, infos)
+}
+
+// 🟦 Screen
+
+func (s *Server) fields(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.lookup(w, r)
+	if ok {
+		reply(w, http.StatusOK, fieldsOf(sess))
+	}
+}
+
+func (s *Server) png(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	img := sess.Image()
+	if img == nil {
+		reply(w, http.StatusConflict, Error{"session is not rendering"})
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	png.Encode(w, img)
+}
+
+func (s *Server) screen(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.lookup(w, r)
+	if ok {
+		reply(w, http.StatusOK, screenOf(sess))
+	}
+}
+
+// 🟦 Actions, each answering the screen as it then is
+
+func (s *Server) cursor(w http.ResponseWriter, r *http.Request) {
+	var req CursorRequest
+	s.act(w, r, &req, func(sess *session.Session) error {
+		return sess.MoveCursor(req.Row, req.Col)
+	})
+}
+
+func (s *Server) fill(w http.ResponseWriter, r *http.Request) {
+	var req FillRequest
+	s.act(w, r, &req, func(sess *session.Session) error {
+		if req.Label != "" {
+			return sess.Fill(req.Label, req.Text)
+		}
+		return sess.FillAt(req.Row, req.Col, req.Text)
+	})
+}
+
+func (s *Server) press(w http.ResponseWriter, r *http.Request) {
+	var req PressRequest
+	s.act(w, r, &req, func(sess *session.Session) error {
+		aid, ok := types.AIDNamed(req.AID)
+		if !ok {
+			return fmt.Errorf("%w: no AID %q", errBadRequest, req.AID)
+		}
+		return sess.Press(aid)
+	})
+}
+
+func (s *Server) typeText(w http.ResponseWriter, r *http.Request) {
+	var req TypeRequest
+	s.act(w, r, &req, func(sess *session.Session) error {
+		return sess.Type(req.Text)
+	})
+}
+
+func (s *Server) wait(w http.ResponseWriter, r *http.Request) {
+	var req WaitRequest
+	s.act(w, r, &req, func(sess *session.Session) error {
+		timeout := s.timeout
+		if req.Timeout != "" {
+			var err error
+			if timeout, err = time.ParseDuration(req.Timeout); err != nil {
+				return fmt.Errorf("%w: %w", errBadRequest, err)
+			}
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		switch strings.ToLower(req.For) {
+
+		case "cursor":
+			return sess.WaitForCursor(ctx, req.Row, req.Col)
+
+		case "input":
+			return sess.WaitForInputField(ctx)
+
+		case "output":
+			return sess.WaitForOutput(ctx)
+
+		case "text":
+			return sess.WaitForText(ctx, req.Text)
+
+		case "unlock", "":
+			return sess.WaitUnlock(ctx)
+
+		}
+		return fmt.Errorf("%w: can't wait for %q", errBadRequest, req.For)
+	})
+}
+
+// 🟦 Helpers
+
+// 👇 decode the request, run the action and reply with the screen
+func (s *Server) act(w http.ResponseWriter, r *http.Request, req any, action func(sess *session.Session) error) {
+	sess, ok := s.lookup(w, r)
+	if !ok || !decode(w, r, req) {
+		return
+	}
+	if err := action(sess); err != nil {
+		fail(w, err)
+		return
+	}
+	reply(w, http.StatusOK, screenOf(sess))
+}
+
+func fieldsOf(sess *session.Session) []Field {
+	flds := make([]Field, 0)
+	for _, fld := range sess.Fields() {
+		flds = append(flds, Field(fld))
+	}
+	return flds
+}
+
+func screenOf(sess *session.Session) Screen {
+	stat := sess.Status()
+	row, col := sess.Cursor()
+	text := strings.Split(strings.TrimSuffix(sess.Text(), "\n"), "\n")
+	return Screen{
+		Cols:   uint(len([]rune(text[0]))),
+		Cursor: Position{Col: col, Row: row},
+		Fields: fieldsOf(sess),
+		Rows:   uint(len(text)),
+		Status: Status{
+			Connected: stat.Connected,
+			Error:     stat.Error,
+			Insert:    stat.Insert,
+			Locked:    stat.Locked,
+			Message:   stat.Message,
+			Numeric:   stat.Numeric,
+			Protected: stat.Protected,
+			Waiting:   stat.Waiting,
+		},
+		Text: text,
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	srv := New(time.Second)
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	addr := mockHost(t)
+	var info SessionInfo
+
+	t.Run("connect", func(t *testing.T) {
+		status := call(t, "POST", ts.URL+"/sessions", ConnectRequest{Addr: addr, Render: true}, &info)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "1", info.ID)
+		var infos []SessionInfo
+		call(t, "GET", ts.URL+"/sessions", nil, &infos)
+		assert.Equal(t, []SessionInfo{{Addr: addr, ID: "1"}}, infos)
+	})
+
+	t.Run("wait for the screen and read it", func(t *testing.T) {
+		var scr Screen
+		status := call(t, "POST", ts.URL+"/sessions/1/wait", WaitRequest{For: "text", Text: "Ready"}, &scr)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, uint(24), scr.Rows)
+		assert.Equal(t, uint(80), scr.Cols)
+		assert.Equal(t, Position{Col: 8, Row: 1}, scr.Cursor)
+		assert.Contains(t, scr.Text[0], "Name:")
+		var flds []Field
+		call(t, "GET", ts.URL+"/sessions/1/fields", nil, &flds)
+		assert.Len(t, flds, 3)
+		assert.True(t, flds[0].Protected)
+	})
+
+	t.Run("type, press and wait", func(t *testing.T) {
+		var scr Screen
+		assert.Equal(t, http.StatusOK, call(t, "POST", ts.URL+"/sessions/1/type", TypeRequest{Text: "mark"}, &scr))
+		assert.Contains(t, scr.Text[0], "mark")
+		assert.Equal(t, http.StatusOK, call(t, "POST", ts.URL+"/sessions/1/press", PressRequest{AID: "enter"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", ts.URL+"/sessions/1/wait", WaitRequest{For: "text", Text: "Hello"}, &scr))
+		assert.Contains(t, scr.Text[0], "Hello")
+	})
+
+	t.Run("fill by label", func(t *testing.T) {
+		var scr Screen
+		assert.Equal(t, http.StatusOK, call(t, "POST", ts.URL+"/sessions/1/fill", FillRequest{Label: "Name:", Text: "bob"}, &scr))
+		assert.Equal(t, "bob", scr.Fields[1].Text[:3])
+	})
+
+	t.Run("the screen as a PNG", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/sessions/1/screen.png")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		img, err := png.Decode(resp.Body)
+		assert.NoError(t, err)
+		assert.Positive(t, img.Bounds().Dx())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var e Error
+		assert.Equal(t, http.StatusNotFound, call(t, "GET", ts.URL+"/sessions/9", nil, &e))
+		assert.Equal(t, "no session 9", e.Error)
+		assert.Equal(t, http.StatusBadRequest, call(t, "POST", ts.URL+"/sessions/1/press", PressRequest{AID: "PF99"}, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, "POST", ts.URL+"/sessions/1/wait", WaitRequest{For: "godot"}, nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, call(t, "POST", ts.URL+"/sessions/1/fill", FillRequest{Label: "Nope"}, nil))
+		assert.Equal(t, http.StatusGatewayTimeout, call(t, "POST", ts.URL+"/sessions/1/wait", WaitRequest{For: "text", Text: "Nope", Timeout: "10ms"}, nil))
+		assert.Equal(t, http.StatusBadGateway, call(t, "POST", ts.URL+"/sessions", ConnectRequest{Addr: "localhost:1"}, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, "POST", ts.URL+"/sessions", nil, nil))
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, call(t, "DELETE", ts.URL+"/sessions/1", nil, nil))
+		assert.Equal(t, http.StatusNotFound, call(t, "DELETE", ts.URL+"/sessions/1", nil, nil))
+		var infos []SessionInfo
+		call(t, "GET", ts.URL+"/sessions", nil, &infos)
+		assert.Empty(t, infos)
+	})
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"emulator/session"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 🟧 Drive headless sessions over HTTP, with JSON in and out
+
+// 👇 POST   /sessions                 connect, answering its id
+//    GET    /sessions                 list sessions
+//    GET    /sessions/{id}            the screen: text, cursor, status, fields
+//    DELETE /sessions/{id}            disconnect
+//    GET    /sessions/{id}/fields     just the fields
+//    GET    /sessions/{id}/screen.png the screen as drawn, if rendering
+//    POST   /sessions/{id}/cursor     move the cursor
+//    POST   /sessions/{id}/fill       replace a field, by label or position
+//    POST   /sessions/{id}/press      press an AID key, like ENTER or PF3
+//    POST   /sessions/{id}/type       type text at the cursor
+//    POST   /sessions/{id}/wait       wait for the host, with a timeout
+
+type Server struct {
+	// 👇 how to connect, replaceable for testing
+	dial    func(ctx context.Context, addr string, opts session.Options) (*session.Session, error)
+	entries map[string]*entry
+	mu      sync.Mutex
+	mux     *http.ServeMux
+	nextID  int
+	timeout time.Duration
+}
+
+type entry struct {
+	addr string
+	sess *session.Session
+}
+
+var errBadRequest = errors.New("bad request")
+
+// 👇 the same shape for every failure
+
+type Error struct {
+	Error string `json:"error"`
+}
+
+// 🟦 Constructor
+
+func New(timeout time.Duration) *Server {
+	s := new(Server)
+	s.dial = session.Connect
+	s.entries = make(map[string]*entry)
+	s.timeout = timeout
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /sessions", s.list)
+	s.mux.HandleFunc("POST /sessions", s.connect)
+	s.mux.HandleFunc("DELETE /sessions/{id}", s.disconnect)
+	s.mux.HandleFunc("GET /sessions/{id}", s.screen)
+	s.mux.HandleFunc("POST /sessions/{id}/cursor", s.cursor)
+	s.mux.HandleFunc("GET /sessions/{id}/fields", s.fields)
+	s.mux.HandleFunc("POST /sessions/{id}/fill", s.fill)
+	s.mux.HandleFunc("POST /sessions/{id}/press", s.press)
+	s.mux.HandleFunc("GET /sessions/{id}/screen.png", s.png)
+	s.mux.HandleFunc("POST /sessions/{id}/type", s.typeText)
+	s.mux.HandleFunc("POST /sessions/{id}/wait", s.wait)
+	return s
+}
+
+// 🟦 Public functions
+
+// 👇 disconnect every session
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, e := range s.entries {
+		e.sess.Close()
+		delete(s.entries, id)
+	}
+}
+
+// 🟦 http.Handler implementation
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// 🟦 Helpers
+
+func (s *Server) add(addr string, sess *session.Session) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	id := strconv.Itoa(s.nextID)
+	s.entries[id] = &entry{addr: addr, sess: sess}
+	return id
+}
+
+// 👇 the session named in the path, or a 404 if there isn't one
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (*session.Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[r.PathValue("id")]
+	if !ok {
+		reply(w, http.StatusNotFound, Error{fmt.Sprintf("no session %s", r.PathValue("id"))})
+		return nil, false
+	}
+	return e.sess, true
+}
+
+// 👇 sorted by id, numerically
+func (s *Server) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.entries))
+	for id := range s.entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	return ids
+}
+
+func (s *Server) remove(id string) (*entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	delete(s.entries, id)
+	return e, ok
+}
+
+// 👇 decode a JSON body, or reply 400 if we can't
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		reply(w, http.StatusBadRequest, Error{fmt.Sprintf("bad request: %s", err)})
+		return false
+	}
+	return true
+}
+
+// 👇 map session errors to HTTP status codes
+func fail(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+
+	case errors.Is(err, errBadRequest):
+		status = http.StatusBadRequest
+
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusGatewayTimeout
+
+	case errors.Is(err, session.ErrClosed):
+		status = http.StatusGone
+
+	case errors.Is(err, session.ErrLocked), errors.Is(err, session.ErrRejected):
+		status = http.StatusConflict
+
+	case errors.Is(err, session.ErrNoField):
+		status = http.StatusUnprocessableEntity
+
+	}
+	reply(w, status, Error{err.Error()})
+}
+
+func reply(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"emulator/session"
+	"emulator/tn3270"
+	"emulator/types"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 a host on a real port that answers every record with a greeting
+func mockHost(t *testing.T) string {
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.Write(tn3270.MockRecord(types.EW, "Ready"))
+				buf := make([]byte, 256)
+				for {
+					if _, err := conn.Read(buf); err != nil {
+						return
+					}
+					conn.Write(tn3270.MockRecord(types.EW, "Hello"))
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+// 👇 call the API, decoding any JSON reply
+func call(t *testing.T, method, url string, body any, v any) int {
+	var rd bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&rd).Encode(body)
+	}
+	req, err := http.NewRequest(method, url, &rd)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	if v != nil {
+		json.NewDecoder(resp.Body).Decode(v)
+	}
+	return resp.StatusCode
+}
+
+func TestFail(t *testing.T) {
+	for err, status := range map[error]int{
+		context.DeadlineExceeded: http.StatusGatewayTimeout,
+		errBadRequest:            http.StatusBadRequest,
+		errors.New("boom"):       http.StatusInternalServerError,
+		session.ErrClosed:        http.StatusGone,
+		session.ErrLocked:        http.StatusConflict,
+		session.ErrNoField:       http.StatusUnprocessableEntity,
+	} {
+		w := httptest.NewRecorder()
+		fail(w, fmt.Errorf("wrapped: %w", err))
+		assert.Equal(t, status, w.Code, err)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/racingmars/go3270"
+	"github.com/stretchr/testify/assert"
+)
+
+// 🟧 End to end against the screens of src/apps/whoru, served by the
+//    same go3270 library, telnet negotiation and all
+
+var whoruScreen1 = go3270.Screen{
+	{Row: 0, Col: 27, Intense: true, Content: "3270 Example Application"},
+	{Row: 2, Col: 0, Content: "Welcome to the go3270 example application. Please enter your name."},
+	{Row: 4, Col: 0, Content: "First Name  . . ."},
+	{Row: 4, Col: 19, Name: "fname", Write: true, Highlighting: go3270.Underscore},
+	{Row: 4, Col: 40, Autoskip: true},
+	{Row: 5, Col: 0, Content: "Last Name . . . ."},
+	{Row: 5, Col: 19, Name: "lname", Write: true, Highlighting: go3270.Underscore},
+	{Row: 5, Col: 40, Autoskip: true},
+	{Row: 6, Col: 0, Content: "Password  . . . ."},
+	{Row: 6, Col: 19, Name: "password", Write: true, Hidden: true},
+	{Row: 6, Col: 40},
+	{Row: 7, Col: 0, Content: "Employee ID . . ."},
+	{Row: 7, Col: 19, Name: "employeeID", Write: true, Highlighting: go3270.Underscore, NumericOnly: true},
+	{Row: 7, Col: 40},
+	{Row: 8, Col: 0, Content: "Press"},
+	{Row: 8, Col: 6, Intense: true, Content: "enter"},
+	{Row: 8, Col: 12, Content: "to submit your name."},
+	{Row: 10, Col: 0, Intense: true, Color: go3270.Red, Name: "errormsg"},
+	{Row: 22, Col: 0, Content: "PF3 Exit"},
+}
+
+var whoruScreen2 = go3270.Screen{
+	{Row: 0, Col: 27, Intense: true, Content: "3270 Example Application"},
+	{Row: 2, Col: 0, Content: "Thank you for submitting your name. Here's what I know:"},
+	{Row: 4, Col: 0, Content: "Your first name is"},
+	{Row: 4, Col: 19, Name: "fname"},
+	{Row: 5, Col: 0, Content: "And your last name is"},
+	{Row: 5, Col: 22, Name: "lname"},
+	{Row: 6, Col: 0, Content: "And your employeed ID is"},
+	{Row: 6, Col: 22, Name: "employeeID"},
+	{Row: 7, Col: 0, Name: "passwordOutput"},
+	{Row: 22, Col: 0, Content: "PF3 Exit"},
+}
+
+var whoruGoodbye = go3270.Screen{
+	{Row: 2, Col: 0, Content: "Thank you using this application. Goodbye."},
+}
+
+// 👇 whoru's conversation, less its pauses and console output
+func whoruHost(t *testing.T) string {
+	l, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go whoruHandle(conn)
+		}
+	}()
+	return l.Addr().String()
+}
+
+func whoruHandle(conn net.Conn) {
+	defer conn.Close()
+	if _, err := go3270.NegotiateTelnet(conn); err != nil {
+		return
+	}
+	values := make(map[string]string)
+	for {
+		values["password"] = ""
+		resp, err := go3270.ShowScreen(whoruScreen1, values, 4, 20, conn)
+		if err != nil {
+			return
+		}
+		switch {
+
+		case resp.AID == go3270.AIDPF3:
+			go3270.ShowScreenNoResponse(whoruGoodbye, nil, 0, 0, conn)
+			return
+
+		case resp.AID != go3270.AIDEnter:
+			continue
+
+		}
+		values = resp.Values
+		if strings.TrimSpace(values["lname"]) == "" {
+			values["errormsg"] = "Last Name field is required."
+			continue
+		}
+		values["errormsg"] = ""
+		values["passwordOutput"] = fmt.Sprintf("Your password was %d characters long", len(strings.TrimSpace(values["password"])))
+		if _, err := go3270.ShowScreen(whoruScreen2, values, 0, 0, conn); err != nil {
+			return
+		}
+	}
+}
+
+func TestServerWhoru(t *testing.T) {
+	srv := New(time.Second)
+	defer srv.Close()
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	var info SessionInfo
+	assert.Equal(t, http.StatusCreated, call(t, "POST", ts.URL+"/sessions", ConnectRequest{Addr: whoruHost(t)}, &info))
+	url := ts.URL + "/sessions/" + info.ID
+	var scr Screen
+
+	t.Run("the first screen, cursor in First Name", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/wait", WaitRequest{For: "text", Text: "First Name"}, &scr))
+		assert.Contains(t, scr.Text[0], "3270 Example Application")
+		assert.Equal(t, Position{Col: 21, Row: 5}, scr.Cursor)
+	})
+
+	t.Run("the host validates what we send", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/type", TypeRequest{Text: "Mark"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/press", PressRequest{AID: "enter"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/wait", WaitRequest{For: "text", Text: "Last Name field is required."}, &scr))
+		// 👇 whoru sends back what we typed
+		assert.Contains(t, scr.Text[4], "Mark")
+	})
+
+	t.Run("fill every field and submit", func(t *testing.T) {
+		for label, text := range map[string]string{"Last Name": "Smith", "Password": "secret", "Employee ID": "1234"} {
+			assert.Equal(t, http.StatusOK, call(t, "POST", url+"/fill", FillRequest{Label: label, Text: text}, nil), label)
+		}
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/press", PressRequest{AID: "enter"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/wait", WaitRequest{For: "text", Text: "Here's what I know"}, &scr))
+		assert.Contains(t, scr.Text[4], "Your first name is Mark")
+		assert.Contains(t, scr.Text[5], "And your last name is Smith")
+		// 👇 whoru's ID field starts on top of the "is" of its label
+		assert.Contains(t, scr.Text[6], "And your employeed ID 1234")
+		assert.Contains(t, scr.Text[7], "Your password was 6 characters long")
+	})
+
+	t.Run("PF3 says goodbye", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/press", PressRequest{AID: "enter"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/wait", WaitRequest{For: "text", Text: "Please enter your name"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/press", PressRequest{AID: "PF3"}, nil))
+		assert.Equal(t, http.StatusOK, call(t, "POST", url+"/wait", WaitRequest{For: "text", Text: "Goodbye."}, nil))
+	})
+}
@@ -0,0 +1,402 @@
+package session
+
+import (
+	"context"
+	"emulator/core"
+	"emulator/export"
+	"emulator/tn3270"
+	"emulator/trace"
+	"emulator/types"
+	"emulator/utils"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// 🟧 Drive the emulator from Go, headless, as a user would
+
+// 👇 the session owns the emulator: a goroutine feeds it whatever the
+//    host sends, and every public function takes the same lock, so
+//    a session may be used from any goroutine
+
+type Session struct {
+	// 👇 the alarm only sounds for an instant, so we remember it
+	alarm   bool
+	bus     *core.Bus
+	changed chan struct{}
+	conn    *tn3270.Conn
+	emu     *core.Emulator
+	err     error
+	mu      sync.Mutex
+	// 👇 nil unless the session is being recorded
+	rec *trace.Recorder
+	// 👇 count records from the host, and where we were when we last sent
+	received int
+	sent     int
+}
+
+type Options struct {
+	Model   string
+	Profile string
+	// 👇 load fonts and draw the screen, so that Image works
+	Render bool
+	// 👇 record the session, for trace.Replay
+	Trace io.Writer
+}
+
+// 👇 a field as the user sees it, row and column of its attribute
+
+type Field struct {
+	Col       uint
+	Hidden    bool
+	Len       uint
+	Modified  bool
+	Numeric   bool
+	Protected bool
+	Row       uint
+	Text      string
+}
+
+var (
+	ErrClosed   = errors.New("session: closed")
+	ErrLocked   = errors.New("session: keyboard locked")
+	ErrNoField  = errors.New("session: no such field")
+	ErrRejected = errors.New("session: keystroke rejected")
+)
+
+// 🟦 Constructors
+
+func Connect(ctx context.Context, addr string, opts Options) (*Session, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Open(conn, opts)
+	if err != nil {
+		conn.Close()
+	}
+	return s, err
+}
+
+// 👇 run a session over any connection, say a pipe for testing
+func Open(conn net.Conn, opts Options) (*Session, error) {
+	model := utils.Ternary(opts.Model == "", "IBM-3278-2-E", opts.Model)
+	rows, cols, ok := tn3270.SizeOf(model)
+	if !ok {
+		return nil, fmt.Errorf("session: unknown model %s", model)
+	}
+	p, ok := types.ProfileOf(utils.Ternary(opts.Profile == "", "3279", opts.Profile))
+	if !ok {
+		return nil, fmt.Errorf("session: unknown profile %s", opts.Profile)
+	}
+	s := new(Session)
+	s.changed = make(chan struct{})
+	s.conn = tn3270.NewConn(conn, model)
+	// 🔥 must subscribe BEFORE we create the emulator
+	s.bus = core.NewBus()
+	s.bus.SubInbound(s.inbound)
+	s.bus.SubPanic(s.panic)
+	s.bus.SubStatus(s.status)
+	if opts.Trace != nil {
+		s.rec = trace.NewRecorder(s.bus, opts.Trace, rows, cols)
+	}
+	cfg, err := core.HeadlessConfig(rows, cols, opts.Render)
+	if err != nil {
+		return nil, fmt.Errorf("session: %w", err)
+	}
+	cfg.ApplyProfile(p)
+	s.emu = core.NewEmulator(s.bus, cfg).Initialize()
+	go s.outbound()
+	return s, nil
+}
+
+// 🟦 Public functions
+
+// 👇 including the first error writing the trace, if recording
+func (s *Session) Close() error {
+	err := s.conn.Close()
+	if s.rec != nil {
+		err = errors.Join(err, s.rec.Err())
+	}
+	return err
+}
+
+// 👇 one-based, as everywhere else
+func (s *Session) Cursor() (row, col uint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.emu.Cfg.Addr2RC(s.emu.State.Status.CursorAt)
+}
+
+// 👇 why the session is no longer usable, if it isn't
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Session) Fields() []Field {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flds := make([]Field, 0, len(s.emu.Flds.Flds))
+	for _, fld := range s.emu.Flds.Flds {
+		sf := fld.Cells[0]
+		addr, _ := sf.GetFldAddr()
+		row, col := s.emu.Cfg.Addr2RC(addr)
+		flds = append(flds, Field{
+			Col:       col,
+			Hidden:    sf.Attrs.Hidden,
+			Len:       uint(len(fld.Cells) - 1),
+			Modified:  sf.Attrs.MDT,
+			Numeric:   sf.Attrs.Numeric,
+			Protected: sf.Attrs.Protected,
+			Row:       row,
+			Text:      fld.String(),
+		})
+	}
+	return flds
+}
+
+// 👇 replace the contents of the first input field after a label
+func (s *Session) Fill(label, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ix, fld := range s.emu.Flds.Flds {
+		if !fld.Cells[0].Attrs.Protected || !strings.Contains(fld.String(), label) {
+			continue
+		}
+		for _, next := range s.emu.Flds.Flds[ix+1:] {
+			if !next.Cells[0].Attrs.Protected && len(next.Cells) > 1 {
+				addr, _ := next.Cells[0].GetFldAddr()
+				return s.fill(s.emu.Buf.WrapAddr(int(addr)+1), len(next.Cells)-1, text)
+			}
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrNoField, label)
+}
+
+// 👇 replace the contents of the input field at a position
+func (s *Session) FillAt(row, col uint, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cell, ok := s.emu.Buf.Peek(s.emu.Cfg.RC2Addr(row, col))
+	if !ok {
+		return fmt.Errorf("%w: %d/%d", ErrNoField, row, col)
+	}
+	fld, ok := cell.FindFld()
+	if !ok || cell.IsFldStart() || fld.Cells[0].Attrs.Protected {
+		return fmt.Errorf("%w: %d/%d", ErrNoField, row, col)
+	}
+	addr := s.emu.Cfg.RC2Addr(row, col)
+	sfAddr, _ := fld.Cells[0].GetFldAddr()
+	used := int(s.emu.Buf.WrapAddr(int(addr)-int(sfAddr))) - 1
+	return s.fill(addr, len(fld.Cells)-1-used, text)
+}
+
+// 👇 a copy of the screen as drawn, or nil if not rendering
+func (s *Session) Image() *image.RGBA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.emu.Cfg.RGBA == nil {
+		return nil
+	}
+	img := image.NewRGBA(s.emu.Cfg.RGBA.Bounds())
+	copy(img.Pix, s.emu.Cfg.RGBA.Pix)
+	return img
+}
+
+// 👇 any other key, as the browser would report it
+func (s *Session) Keystroke(key types.Keystroke) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keystroke(key)
+}
+
+func (s *Session) MoveCursor(row, col uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if row < 1 || row > s.emu.Cfg.Rows || col < 1 || col > s.emu.Cfg.Cols {
+		return fmt.Errorf("session: no position %d/%d", row, col)
+	}
+	s.moveCursor(s.emu.Cfg.RC2Addr(row, col))
+	return nil
+}
+
+// 👇 ENTER, CLEAR, PFn or PAn, as if pressed on the keyboard
+func (s *Session) Press(aid types.AID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := keystrokeFor(aid)
+	if !ok {
+		return fmt.Errorf("session: can't press %s", aid)
+	}
+	return s.keystroke(key)
+}
+
+// 👇 RESET, as the operator would to clear eg: X PROG, even when locked
+func (s *Session) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.bus.PubKeystroke(types.Keystroke{CTRL: true, Code: "KeyR", Key: "r"})
+	return nil
+}
+
+func (s *Session) Status() types.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.emu.State.Status
+}
+
+// 👇 the whole screen, one line per row
+func (s *Session) Text() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.text()
+}
+
+// 👇 count characters starting at a position, wrapping rows
+func (s *Session) TextAt(row, col, count uint) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cells := export.NewSnapshot(s.emu).Cells
+	from := s.emu.Cfg.RC2Addr(row, col)
+	runes := make([]rune, 0, count)
+	for ix := uint(0); ix < count && from+ix < uint(len(cells)); ix++ {
+		runes = append(runes, cells[from+ix].Rune)
+	}
+	return string(runes)
+}
+
+// 👇 type text at the cursor, where \t is the Tab key
+func (s *Session) Type(text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.typeText(text)
+}
+
+// 🟦 Helpers
+
+func (s *Session) fill(addr uint, room int, text string) error {
+	s.moveCursor(addr)
+	// 👇 Delete at the start of the field shuffles everything away
+	for range room {
+		if err := s.keystroke(types.Keystroke{Code: "Delete", Key: "Delete"}); err != nil {
+			break
+		}
+	}
+	return s.typeText(text)
+}
+
+func (s *Session) inbound(chars []byte, _ core.PubInboundHints) {
+	s.sent = s.received
+	if err := s.conn.Write(chars); err != nil && s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *Session) keystroke(key types.Keystroke) error {
+	if s.err != nil {
+		return s.err
+	}
+	// 👇 say why, if the host rejected what it sent us
+	if stat := s.emu.State.Status; stat.Locked {
+		return utils.Ternary(stat.Error, fmt.Errorf("%w: X %s", ErrLocked, stat.Message), ErrLocked)
+	}
+	s.alarm = false
+	s.bus.PubKeystroke(key)
+	if s.alarm {
+		row, col := s.emu.Cfg.Addr2RC(s.emu.State.Status.CursorAt)
+		return fmt.Errorf("%w: %q at %d/%d", ErrRejected, key.Key, row, col)
+	}
+	return nil
+}
+
+// 🔥 via the bus, so that a trace can record it
+func (s *Session) moveCursor(addr uint) {
+	s.bus.PubCursor(addr)
+}
+
+// 👇 wake up anyone waiting for the screen to change
+func (s *Session) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// 👇 feed the emulator whatever the host sends, until it stops
+func (s *Session) outbound() {
+	for {
+		record, err := s.conn.Read()
+		s.mu.Lock()
+		if err != nil {
+			s.err = utils.Ternary(errors.Is(err, net.ErrClosed), ErrClosed, err)
+			// 👇 so the status shows we are no longer connected
+			s.bus.PubClose()
+			s.notify()
+			s.mu.Unlock()
+			return
+		}
+		s.bus.PubOutbound(record)
+		s.received++
+		s.notify()
+		s.mu.Unlock()
+	}
+}
+
+func (s *Session) panic(msg string) {
+	s.err = fmt.Errorf("session: %s", msg)
+}
+
+func (s *Session) status(stat *types.Status) {
+	s.alarm = s.alarm || stat.Alarm
+	s.notify()
+}
+
+func (s *Session) text() string {
+	return export.Text(export.NewSnapshot(s.emu), false)
+}
+
+func (s *Session) typeText(text string) error {
+	for _, r := range text {
+		key := types.Keystroke{Code: "Key" + strings.ToUpper(string(r)), Key: string(r)}
+		if r == '\t' {
+			key = types.Keystroke{Code: "Tab", Key: "Tab"}
+		}
+		if err := s.keystroke(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 👇 the keystroke the keyboard maps to an AID
+func keystrokeFor(aid types.AID) (types.Keystroke, bool) {
+	name := aid.String()
+	switch {
+
+	case aid == types.ENTER:
+		return types.Keystroke{Code: "Enter", Key: "Enter"}, true
+
+	case aid == types.CLEAR:
+		return types.Keystroke{Code: "Escape", Key: "Escape"}, true
+
+	case aid.PAx():
+		fkey := "F" + strings.TrimPrefix(name, "PA")
+		return types.Keystroke{ALT: true, Code: fkey, Key: fkey}, true
+
+	case aid.PFx():
+		var num int
+		fmt.Sscanf(name, "PF%d", &num)
+		fkey := fmt.Sprintf("F%d", utils.Ternary(num > 12, num-12, num))
+		return types.Keystroke{Code: fkey, Key: fkey, SHIFT: num > 12}, true
+
+	}
+	return types.Keystroke{}, false
+}
@@ -0,0 +1,228 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"emulator/conv"
+	"emulator/core"
+	"emulator/datastream"
+	"emulator/tn3270"
+	"emulator/trace"
+	"emulator/types"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSession(t *testing.T) (*Session, net.Conn) {
+	host, client := net.Pipe()
+	s, err := Open(client, Options{})
+	assert.NoError(t, err)
+	host.Write(tn3270.MockRecord(types.EW, "Ready"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.WaitForText(ctx, "Ready"))
+	return s, host
+}
+
+// 👇 read one inbound record from the session
+func readRecord(host net.Conn) []byte {
+	var record []byte
+	buf := make([]byte, 256)
+	for !bytes.HasSuffix(record, []byte{tn3270.IAC, tn3270.EOR}) {
+		n, err := host.Read(buf)
+		if err != nil {
+			break
+		}
+		record = append(record, buf[:n]...)
+	}
+	return record
+}
+
+func TestSession(t *testing.T) {
+	s, host := mockSession(t)
+	defer s.Close()
+
+	t.Run("reads the screen", func(t *testing.T) {
+		assert.Equal(t, "Name:", s.TextAt(1, 2, 5))
+		row, col := s.Cursor()
+		assert.Equal(t, []uint{1, 8}, []uint{row, col})
+		flds := s.Fields()
+		assert.Len(t, flds, 3)
+		assert.Equal(t, Field{Col: 7, Len: 12, Row: 1}, flds[1])
+	})
+
+	t.Run("fills a field by label", func(t *testing.T) {
+		assert.NoError(t, s.FillAt(1, 8, "nobody"))
+		assert.NoError(t, s.Fill("Name:", "mark"))
+		assert.Equal(t, "mark        ", s.TextAt(1, 8, 12))
+		assert.True(t, s.Fields()[1].Modified)
+		assert.ErrorIs(t, s.Fill("Age:", "42"), ErrNoField)
+		assert.ErrorIs(t, s.FillAt(1, 2, "oops"), ErrNoField)
+	})
+
+	t.Run("typing into protected data is rejected", func(t *testing.T) {
+		assert.NoError(t, s.MoveCursor(1, 3))
+		assert.ErrorIs(t, s.Type("x"), ErrRejected)
+	})
+
+	t.Run("press ENTER and wait for the host", func(t *testing.T) {
+		reply := make(chan []byte)
+		go func() { reply <- readRecord(host) }()
+		assert.NoError(t, s.Press(types.ENTER))
+		inbound := <-reply
+		assert.Equal(t, byte(types.ENTER), inbound[0])
+		assert.Contains(t, string(inbound), conv.A2Es("mark"))
+		// 👇 the keyboard is locked until the host answers
+		assert.ErrorIs(t, s.Type("x"), ErrLocked)
+		go host.Write(tn3270.MockRecord(types.W, "Hello mark"))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, s.WaitUnlock(ctx))
+		assert.NoError(t, s.WaitForText(ctx, "Hello mark"))
+		assert.NoError(t, s.WaitForCursor(ctx, 1, 8))
+	})
+
+	t.Run("headless sessions draw nothing", func(t *testing.T) {
+		assert.Nil(t, s.Image())
+	})
+}
+
+func TestSessionProgCheck(t *testing.T) {
+	s, host := mockSession(t)
+	defer s.Close()
+	// 👇 a record we must reject, then one we can apply
+	host.Write(tn3270.MockFrame([]byte{byte(types.W), 0x00, byte(types.PT)}))
+	stream := datastream.NewBuilder(24, 80).
+		Command(types.W).WCC(types.WCC{Unlock: true}).
+		SBA(2, 1).Text("Still here").
+		MustBytes()
+	host.Write(tn3270.MockFrame(stream))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.WaitForText(ctx, "Still here"))
+	assert.NoError(t, s.Err())
+	err := s.Type("x")
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.ErrorContains(t, err, "X PROG 753")
+	assert.NoError(t, s.Reset())
+	assert.NoError(t, s.Type("x"))
+}
+
+func TestSessionDisconnect(t *testing.T) {
+	s, host := mockSession(t)
+	defer s.Close()
+	assert.True(t, s.Status().Connected)
+	host.Close()
+	// 👇 any wait ends as soon as the host has gone
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Error(t, s.WaitForText(ctx, "never"))
+	assert.Error(t, s.Err())
+	assert.False(t, s.Status().Connected)
+	assert.True(t, s.Status().Locked)
+}
+
+func TestSessionRender(t *testing.T) {
+	host, client := net.Pipe()
+	defer host.Close()
+	s, err := Open(client, Options{Render: true})
+	assert.NoError(t, err)
+	defer s.Close()
+	host.Write(tn3270.MockRecord(types.EW, "Ready"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.WaitForText(ctx, "Ready"))
+	img := s.Image()
+	assert.NotNil(t, img)
+	assert.Equal(t, core.CanvasRect(s.emu.Cfg), img.Bounds())
+	// 👇 something other than background must have been drawn
+	bg := img.RGBAAt(0, 0)
+	drawn := false
+	for ix := 0; ix < len(img.Pix) && !drawn; ix += 4 {
+		drawn = img.Pix[ix] != bg.R || img.Pix[ix+1] != bg.G || img.Pix[ix+2] != bg.B
+	}
+	assert.True(t, drawn)
+}
+
+func TestSessionTrace(t *testing.T) {
+	var b bytes.Buffer
+	host, client := net.Pipe()
+	defer host.Close()
+	s, err := Open(client, Options{Trace: &b})
+	assert.NoError(t, err)
+	defer s.Close()
+	host.Write(tn3270.MockRecord(types.EW, "Ready"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.WaitForText(ctx, "Ready"))
+	tr, err := trace.Read(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(24), tr.Header.Rows)
+	assert.Equal(t, trace.OUTBOUND, tr.Events[0].Kind)
+}
+
+// 👇 cursor moves are neither keystrokes nor host data, but the
+//    answer to the host depends on them just the same
+
+func TestSessionTraceReplay(t *testing.T) {
+	var b bytes.Buffer
+	host, client := net.Pipe()
+	defer host.Close()
+	s, err := Open(client, Options{Trace: &b})
+	assert.NoError(t, err)
+	host.Write(tn3270.MockRecord(types.EW, "Ready"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, s.WaitForText(ctx, "Ready"))
+	assert.NoError(t, s.Fill("Name:", "mark"))
+	assert.NoError(t, s.MoveCursor(1, 16))
+	assert.NoError(t, s.Type("x"))
+	reply := make(chan []byte)
+	go func() { reply <- readRecord(host) }()
+	assert.NoError(t, s.Press(types.ENTER))
+	<-reply
+	assert.NoError(t, s.Close())
+	tr, err := trace.Read(&b)
+	assert.NoError(t, err)
+	assert.Contains(t, kindsOf(tr), trace.CURSOR)
+	emu, mismatches, err := trace.Replay(tr)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+	assert.Equal(t, "mark    x", emu.Cells.Copy(emu.Cfg.RC2Addr(1, 8), emu.Cfg.RC2Addr(1, 16), false))
+}
+
+func kindsOf(tr *trace.Trace) []trace.Kind {
+	kinds := make([]trace.Kind, 0, len(tr.Events))
+	for _, evt := range tr.Events {
+		kinds = append(kinds, evt.Kind)
+	}
+	return kinds
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestSessionTraceErr(t *testing.T) {
+	host, client := net.Pipe()
+	defer host.Close()
+	s, err := Open(client, Options{Trace: failingWriter{}})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, s.Close(), "disk full")
+}
+
+func TestKeystrokeFor(t *testing.T) {
+	for _, aid := range []types.AID{types.ENTER, types.CLEAR, types.PA2, types.PF3, types.PF15} {
+		key, ok := keystrokeFor(aid)
+		assert.True(t, ok)
+		assert.Equal(t, aid, types.AIDOf(key.Key, key.ALT, key.CTRL, key.SHIFT), aid)
+	}
+	_, ok := keystrokeFor(types.SELPEN)
+	assert.False(t, ok)
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"context"
+	"strings"
+)
+
+// 🟧 Wait for the host, until the screen is as expected or the
+//    context is done
+
+// 🟦 Public functions
+
+func (s *Session) WaitForCursor(ctx context.Context, row, col uint) error {
+	return s.wait(ctx, func() bool {
+		r, c := s.emu.Cfg.Addr2RC(s.emu.State.Status.CursorAt)
+		return r == row && c == col
+	})
+}
+
+// 👇 the keyboard is unlocked on a screen with somewhere to type
+func (s *Session) WaitForInputField(ctx context.Context) error {
+	return s.wait(ctx, func() bool {
+		if s.emu.State.Status.Locked || s.emu.State.Status.Waiting {
+			return false
+		}
+		for _, fld := range s.emu.Flds.Flds {
+			if !fld.Cells[0].Attrs.Protected {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// 👇 the host has sent something since we last sent anything
+func (s *Session) WaitForOutput(ctx context.Context) error {
+	return s.wait(ctx, func() bool {
+		return s.received > s.sent
+	})
+}
+
+func (s *Session) WaitForText(ctx context.Context, text string) error {
+	return s.wait(ctx, func() bool {
+		return strings.Contains(s.text(), text)
+	})
+}
+
+// 👇 the host has answered and the keyboard is ours again
+func (s *Session) WaitUnlock(ctx context.Context) error {
+	return s.wait(ctx, func() bool {
+		return !s.emu.State.Status.Locked && !s.emu.State.Status.Waiting
+	})
+}
+
+// 🟦 Helpers
+
+// 🔥 cond is called with the lock held
+func (s *Session) wait(ctx context.Context, cond func() bool) error {
+	for {
+		s.mu.Lock()
+		ok, err, changed := cond(), s.err, s.changed
+		s.mu.Unlock()
+		switch {
+
+		case ok:
+			return nil
+
+		case err != nil:
+			return err
+
+		}
+		select {
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-changed:
+
+		}
+	}
+}
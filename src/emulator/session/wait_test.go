@@ -0,0 +1,46 @@
+package session
+
+import (
+	"context"
+	"emulator/tn3270"
+	"emulator/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWait(t *testing.T) {
+	s, host := mockSession(t)
+
+	t.Run("waits honor the context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, s.WaitForText(ctx, "never"), context.DeadlineExceeded)
+		assert.ErrorIs(t, s.WaitForCursor(ctx, 24, 80), context.DeadlineExceeded)
+	})
+
+	t.Run("waits for output and input fields", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, s.WaitForInputField(ctx))
+		go readRecord(host)
+		assert.NoError(t, s.Press(types.ENTER))
+		// 👇 nothing from the host yet
+		short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, s.WaitForOutput(short), context.DeadlineExceeded)
+		go host.Write(tn3270.MockRecord(types.W, "Again"))
+		assert.NoError(t, s.WaitForOutput(ctx))
+		assert.NoError(t, s.WaitForInputField(ctx))
+	})
+
+	t.Run("waits end when the host goes away", func(t *testing.T) {
+		host.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.Error(t, s.WaitForText(ctx, "never"))
+		assert.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+		assert.Error(t, s.Err())
+	})
+}
@@ -0,0 +1,160 @@
+//go:build dev
+
+package snapshots
+
+import (
+	"emulator/pcap"
+	"emulator/trace"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 🟧 Load more snapshots at runtime, from trace files or captures,
+//    so that a new test page doesn't need a recompile
+
+// 👇 $GO3270_SNAPSHOTS names a directory of them, loaded at startup,
+//    $GO3270_SNAPSHOTS_PORT the host's port in any capture, and
+//    $GO3270_SNAPSHOTS_QUIET keeps any error to LoadErr alone
+
+var (
+	// 👇 why $GO3270_SNAPSHOTS didn't load, if it didn't
+	LoadErr error
+	// 👇 the host's port in a capture, where 0 takes the lower port
+	Port uint16 = 3270
+)
+
+// 🔥 runs after index.go's init, as Go goes in file name order
+func init() {
+	LoadErr = loadEnv()
+	if LoadErr != nil && os.Getenv("GO3270_SNAPSHOTS_QUIET") == "" {
+		fmt.Fprintln(os.Stderr, "🔥", LoadErr)
+	}
+}
+
+// 🟦 Public functions
+
+// 👇 each outbound record as a snapshot: name, or name-1, name-2 ...
+func Add(name string, t *trace.Trace) []string {
+	streams := make([][]byte, 0)
+	for _, evt := range t.Events {
+		if evt.Kind == trace.OUTBOUND {
+			streams = append(streams, evt.Data)
+		}
+	}
+	names := make([]string, 0, len(streams))
+	for ix, stream := range streams {
+		nm := name
+		if len(streams) > 1 {
+			nm = fmt.Sprintf("%s-%d", name, ix+1)
+		}
+		Index[nm] = stream
+		names = append(names, nm)
+	}
+	return names
+}
+
+// 👇 every trace, x3270 trace and capture in a directory
+func LoadDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || kindOf(entry.Name()) == "" {
+			continue
+		}
+		added, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return names, err
+		}
+		names = append(names, added...)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// 👇 a snapshot named after the file, less its extension
+func LoadFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	traces := make([]*trace.Trace, 0)
+	switch kindOf(path) {
+
+	case trace.SOURCE_PCAP:
+		traces, err = pcap.Import(f, Port)
+
+	case trace.SOURCE_RECORDER:
+		var t *trace.Trace
+		t, err = trace.Read(f)
+		traces = append(traces, t)
+
+	case trace.SOURCE_X3270:
+		var t *trace.Trace
+		t, err = trace.ImportX3270(f)
+		traces = append(traces, t)
+
+	default:
+		err = fmt.Errorf("don't know how to load %s", path)
+
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshots: %s: %w", path, err)
+	}
+	// 👇 each connection of a capture gets its own name
+	names := make([]string, 0)
+	for ix, t := range traces {
+		nm := name
+		if len(traces) > 1 {
+			nm = fmt.Sprintf("%s-c%d", name, ix+1)
+		}
+		names = append(names, Add(nm, t)...)
+	}
+	return names, nil
+}
+
+// 🟦 Helpers
+
+func loadEnv() error {
+	if port := os.Getenv("GO3270_SNAPSHOTS_PORT"); port != "" {
+		n, err := strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return fmt.Errorf("snapshots: $GO3270_SNAPSHOTS_PORT %q is not a port", port)
+		}
+		Port = uint16(n)
+	}
+	if dir := os.Getenv("GO3270_SNAPSHOTS"); dir != "" {
+		if _, err := LoadDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 👇 by extension, or x3270's own x3trc.PID.txt
+func kindOf(path string) string {
+	if strings.HasPrefix(filepath.Base(path), "x3trc") {
+		return trace.SOURCE_X3270
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+
+	case ".cap", ".pcap", ".pcapng":
+		return trace.SOURCE_PCAP
+
+	case ".jsonl", ".trace":
+		return trace.SOURCE_RECORDER
+
+	case ".trc":
+		return trace.SOURCE_X3270
+
+	}
+	return ""
+}
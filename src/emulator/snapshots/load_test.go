@@ -0,0 +1,105 @@
+//go:build dev
+
+package snapshots
+
+import (
+	"bytes"
+	"emulator/pcap"
+	"emulator/trace"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	tr := &trace.Trace{
+		Header: trace.Header{Cols: 80, Rows: 24, Version: trace.Version},
+		Events: []trace.Event{
+			{Data: trace.Hex{0xf5, 0xc2}, Kind: trace.OUTBOUND},
+			{Data: trace.Hex{0x7d}, Kind: trace.INBOUND},
+			{Data: trace.Hex{0xf1, 0xc2}, Kind: trace.OUTBOUND},
+		},
+	}
+	f, _ := os.Create(filepath.Join(dir, "mine.trace"))
+	assert.NoError(t, tr.Write(f))
+	f.Close()
+	os.WriteFile(filepath.Join(dir, "x3trc.123.txt"), []byte("< 0x0   f5c3ffef\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0644)
+
+	names, err := LoadDir(dir)
+	t.Cleanup(func() {
+		for _, nm := range names {
+			delete(Index, nm)
+		}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mine-1", "mine-2", "x3trc.123"}, names)
+	assert.Equal(t, []byte{0xf1, 0xc2}, Index["mine-2"])
+	assert.Equal(t, []byte{0xf5, 0xc3}, Index["x3trc.123"])
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.trace"))
+	assert.Error(t, err)
+	path := filepath.Join(t.TempDir(), "bad.pcap")
+	os.WriteFile(path, []byte("not a capture"), 0644)
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "bad.pcap")
+}
+
+// 👇 a client on port 1023, below the host's 3270, sends a SYN and the
+//    host answers with one record -- as a classic pcap file
+
+func mockCapture() []byte {
+	frame := func(src, dst uint16, flags byte, payload []byte) []byte {
+		f := make([]byte, 54, 54+len(payload))
+		binary.BigEndian.PutUint16(f[12:], pcap.ETHERTYPE_IPV4)
+		f[14] = 0x45
+		binary.BigEndian.PutUint16(f[16:], uint16(40+len(payload)))
+		f[23] = pcap.PROTO_TCP
+		copy(f[26:], []byte{10, 0, 0, byte(src % 2), 10, 0, 0, byte(dst % 2)})
+		binary.BigEndian.PutUint16(f[34:], src)
+		binary.BigEndian.PutUint16(f[36:], dst)
+		f[46] = 5 << 4
+		f[47] = flags
+		return append(f, payload...)
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, []uint32{pcap.PCAP_MICROS, 0x00040002, 0, 0, 65535, uint32(pcap.LINKTYPE_ETHERNET)})
+	for ix, f := range [][]byte{frame(1023, 3270, 0x02, nil), frame(3270, 1023, 0x10, []byte{0xf5, 0xc2, 0xff, 0xef})} {
+		binary.Write(&b, binary.LittleEndian, []uint32{uint32(1000 + ix), 0, uint32(len(f)), uint32(len(f))})
+		b.Write(f)
+	}
+	return b.Bytes()
+}
+
+func TestLoadEnv(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "low.pcap"), mockCapture(), 0644)
+	t.Cleanup(func() {
+		Port = 3270
+		delete(Index, "low")
+	})
+
+	t.Run("the host is on port 3270 by default", func(t *testing.T) {
+		t.Setenv("GO3270_SNAPSHOTS", dir)
+		assert.NoError(t, loadEnv())
+		assert.Equal(t, []byte{0xf5, 0xc2}, Index["low"])
+	})
+
+	t.Run("or on the port we are told", func(t *testing.T) {
+		t.Setenv("GO3270_SNAPSHOTS", dir)
+		t.Setenv("GO3270_SNAPSHOTS_PORT", "23")
+		assert.ErrorContains(t, loadEnv(), "port 23")
+		assert.Equal(t, uint16(23), Port)
+	})
+
+	t.Run("which must be a port", func(t *testing.T) {
+		t.Setenv("GO3270_SNAPSHOTS_PORT", "telnet")
+		assert.ErrorContains(t, loadEnv(), "not a port")
+	})
+}
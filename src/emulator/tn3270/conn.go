@@ -0,0 +1,234 @@
+package tn3270
+
+import (
+	"bufio"
+	"bytes"
+	"emulator/types"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// 🟧 3270 Telnet transport, the Go twin of client/services/tn3270.ts
+
+// 👁️ https://tools.ietf.org/html/rfc1576
+// 👁️ https://tools.ietf.org/html/rfc1647
+// 👁️ http://users.cs.cf.ac.uk/Dave.Marshall/Internet/node141.html
+
+type Conn struct {
+	conn  net.Conn
+	model string
+	rd    *bufio.Reader
+	// 👇 options we have already asked the host to DO
+	asked map[byte]bool
+	// 🔥 negotiation responses and inbound data may race
+	mu sync.Mutex
+}
+
+// 🟦 Lookup tables
+
+const (
+	BINARY        byte = 0
+	DO            byte = 253
+	DONT          byte = 254
+	EOR           byte = 239
+	IAC           byte = 255
+	OPT_EOR       byte = 25
+	SB            byte = 250
+	SE            byte = 240
+	TERMINAL_TYPE byte = 24
+	WILL          byte = 251
+	WONT          byte = 252
+)
+
+const (
+	IS   byte = 0
+	SEND byte = 1
+)
+
+// 👇 the models we can claim, by size
+var models = map[string][2]uint{
+	"IBM-3278-2-E": {24, 80},
+	"IBM-3278-3-E": {32, 80},
+	"IBM-3278-4-E": {43, 80},
+	"IBM-3278-5-E": {27, 132},
+}
+
+// 🟦 Constructors
+
+func Dial(addr, model string, timeout time.Duration) (*Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn, model), nil
+}
+
+// 👇 wrap any connection, say a pipe for testing
+func NewConn(conn net.Conn, model string) *Conn {
+	c := new(Conn)
+	c.asked = make(map[byte]bool)
+	c.conn = conn
+	c.model = model
+	c.rd = bufio.NewReader(conn)
+	return c
+}
+
+// 🟦 Public functions
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// 👇 the next 3270 data record, negotiating Telnet options on the way
+func (c *Conn) Read() ([]byte, error) {
+	var record bytes.Buffer
+	for {
+		char, err := c.rd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if char != IAC {
+			record.WriteByte(char)
+			continue
+		}
+		cmd, err := c.rd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch cmd {
+
+		// 👇 doubled IAC is a data byte
+		case IAC:
+			record.WriteByte(IAC)
+
+		case EOR:
+			if record.Len() > 0 {
+				return record.Bytes(), nil
+			}
+
+		case DO, DONT, WILL, WONT:
+			opt, err := c.rd.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if err := c.negotiate(cmd, opt); err != nil {
+				return nil, err
+			}
+
+		case SB:
+			sub, err := c.readSub()
+			if err != nil {
+				return nil, err
+			}
+			if err := c.subnegotiate(sub); err != nil {
+				return nil, err
+			}
+
+		}
+	}
+}
+
+// 👇 send inbound data, as produced by the emulator, to the host
+func (c *Conn) Write(chars []byte) error {
+	// 🔥 the emulator frames its data with LT, which is IAC EOR
+	chars = bytes.TrimSuffix(chars, types.LT)
+	escaped := bytes.ReplaceAll(chars, []byte{IAC}, []byte{IAC, IAC})
+	return c.send(append(escaped, IAC, EOR))
+}
+
+// 🟦 Models
+
+// 👇 the model for a screen size, if it is a standard one
+func ModelFor(rows, cols uint) (string, bool) {
+	for model, size := range models {
+		if size == [2]uint{rows, cols} {
+			return model, true
+		}
+	}
+	return "", false
+}
+
+// 👇 the screen size for a model
+func SizeOf(model string) (rows, cols uint, ok bool) {
+	size, ok := models[model]
+	return size[0], size[1], ok
+}
+
+// 🟦 Helpers
+
+func (c *Conn) negotiate(cmd, opt byte) error {
+	supported := opt == BINARY || opt == OPT_EOR || opt == TERMINAL_TYPE
+	switch {
+
+	case cmd == DO && supported:
+		reply := []byte{IAC, WILL, opt}
+		// 👇 binary and EOR must flow both ways
+		if opt != TERMINAL_TYPE && !c.asked[opt] {
+			reply = append(reply, IAC, DO, opt)
+			c.asked[opt] = true
+		}
+		return c.send(reply)
+
+	case cmd == DO:
+		return c.send([]byte{IAC, WONT, opt})
+
+	case cmd == WILL && supported && !c.asked[opt]:
+		c.asked[opt] = true
+		return c.send([]byte{IAC, DO, opt})
+
+	case cmd == WILL && !supported:
+		return c.send([]byte{IAC, DONT, opt})
+
+	}
+	return nil
+}
+
+// 🔥 SE (0xf0) may well appear as data, so only IAC SE ends a
+// subnegotiation, and within it IAC IAC is a data byte
+func (c *Conn) readSub() ([]byte, error) {
+	var sub []byte
+	for {
+		char, err := c.rd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if char != IAC {
+			sub = append(sub, char)
+			continue
+		}
+		cmd, err := c.rd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch cmd {
+
+		case IAC:
+			sub = append(sub, IAC)
+
+		case SE:
+			return sub, nil
+
+		}
+	}
+}
+
+func (c *Conn) subnegotiate(sub []byte) error {
+	// 👇 sub is everything after IAC SB, up to but excluding IAC SE
+	if len(sub) >= 2 && sub[0] == TERMINAL_TYPE && sub[1] == SEND {
+		reply := []byte{IAC, SB, TERMINAL_TYPE, IS}
+		reply = append(reply, []byte(c.model)...)
+		return c.send(append(reply, IAC, SE))
+	}
+	return nil
+}
+
+func (c *Conn) send(chars []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.conn.Write(chars); err != nil {
+		return fmt.Errorf("tn3270: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tn3270
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 a host that negotiates, then sends a record with an escaped IAC
+func mockHost(t *testing.T, host net.Conn, replies chan<- []byte) {
+	expect := func(send []byte, n int) {
+		host.Write(send)
+		buf := make([]byte, n)
+		total := 0
+		for total < n {
+			count, err := host.Read(buf[total:])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			total += count
+		}
+		replies <- buf
+	}
+	expect([]byte{IAC, DO, TERMINAL_TYPE}, 3)
+	expect([]byte{IAC, SB, TERMINAL_TYPE, SEND, IAC, SE}, 4+len("IBM-3278-2-E")+2)
+	expect([]byte{IAC, DO, OPT_EOR}, 6)
+	expect([]byte{IAC, DO, 99}, 3)
+	host.Write([]byte{0xf5, 0xc3, IAC, IAC, 0x11, IAC, EOR})
+	close(replies)
+}
+
+func TestConn(t *testing.T) {
+	host, client := net.Pipe()
+	defer host.Close()
+	c := NewConn(client, "IBM-3278-2-E")
+	replies := make(chan []byte, 8)
+	go mockHost(t, host, replies)
+
+	t.Run("negotiates and reads a record", func(t *testing.T) {
+		record, err := c.Read()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xf5, 0xc3, 0xff, 0x11}, record)
+		assert.Equal(t, []byte{IAC, WILL, TERMINAL_TYPE}, <-replies)
+		assert.Equal(t, append(append([]byte{IAC, SB, TERMINAL_TYPE, IS}, "IBM-3278-2-E"...), IAC, SE), <-replies)
+		assert.Equal(t, []byte{IAC, WILL, OPT_EOR, IAC, DO, OPT_EOR}, <-replies)
+		assert.Equal(t, []byte{IAC, WONT, 99}, <-replies, "unsupported options are refused")
+	})
+
+	t.Run("writes a record", func(t *testing.T) {
+		go c.Write([]byte{0x7d, 0xff, 0x40, IAC, EOR})
+		buf := make([]byte, 6)
+		n, _ := host.Read(buf)
+		assert.True(t, bytes.Equal([]byte{0x7d, IAC, IAC, 0x40, IAC, EOR}, buf[:n]))
+	})
+}
+
+func TestConnSubnegotiation(t *testing.T) {
+	host, client := net.Pipe()
+	defer host.Close()
+	c := NewConn(client, "IBM-3278-2-E")
+	// 👇 an option we ignore, whose data has SE and an escaped IAC
+	go host.Write([]byte{IAC, SB, 99, SE, IAC, IAC, 0x01, IAC, SE, 0xf1, 0xc3, IAC, EOR})
+	record, err := c.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xf1, 0xc3}, record)
+}
+
+func TestModels(t *testing.T) {
+	model, ok := ModelFor(27, 132)
+	assert.True(t, ok)
+	assert.Equal(t, "IBM-3278-5-E", model)
+	rows, cols, ok := SizeOf("IBM-3278-3-E")
+	assert.True(t, ok)
+	assert.Equal(t, []uint{32, 80}, []uint{rows, cols})
+	_, ok = ModelFor(25, 80)
+	assert.False(t, ok)
+}
@@ -0,0 +1,42 @@
+//go:build dev
+
+package tn3270
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/types"
+)
+
+// 🟧 Fabricate what a host would send, for the tests of everything
+//    that drives a session
+
+// 👇 "Name:" then a 13 character input field, with the cursor in it,
+//    then any greeting at row 1, column 21 -- all on a 24x80 screen
+
+func MockScreen(cmd types.Command, greeting string) []byte {
+	prot := &types.Attrs{Protected: true}
+	stream := []byte{byte(cmd), types.WCC{Unlock: true}.Bits()}
+	stream = append(stream, byte(types.SBA))
+	stream = append(stream, conv.Addr2Bytes(0)...)
+	stream = append(stream, byte(types.SF), prot.Bits())
+	stream = append(stream, conv.A2Es("Name:")...)
+	stream = append(stream, byte(types.SF), (&types.Attrs{}).Bits(), byte(types.IC))
+	stream = append(stream, byte(types.SBA))
+	stream = append(stream, conv.Addr2Bytes(19)...)
+	stream = append(stream, byte(types.SF), prot.Bits())
+	return append(stream, conv.A2Es(greeting)...)
+}
+
+// 👇 the same, framed as a record on the wire
+
+func MockRecord(cmd types.Command, greeting string) []byte {
+	return MockFrame(MockScreen(cmd, greeting))
+}
+
+// 👇 any stream framed as a record, doubling IAC as the host must
+
+func MockFrame(stream []byte) []byte {
+	record := bytes.ReplaceAll(stream, []byte{IAC}, []byte{IAC, IAC})
+	return append(record, IAC, EOR)
+}
@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"emulator/core"
+	"emulator/types"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// 🟧 Record a session's events to a trace file as they happen
+
+// 🔥 subscribe BEFORE creating the emulator, so that each outbound
+//    stream, keystroke or cursor move is recorded before the inbound
+//    it provokes
+
+type Recorder struct {
+	enc   *json.Encoder
+	err   error
+	mu    sync.Mutex
+	start time.Time
+}
+
+// 🟦 Constructor
+
+func NewRecorder(bus *core.Bus, w io.Writer, rows, cols uint) *Recorder {
+	r := new(Recorder)
+	r.enc = json.NewEncoder(w)
+	r.start = time.Now()
+	// 👇 the header goes out at once
+	r.err = r.enc.Encode(Header{
+		Cols:    cols,
+		Rows:    rows,
+		Source:  SOURCE_RECORDER,
+		Started: r.start,
+		Version: Version,
+	})
+	// 👇 subscriptions
+	bus.SubCursor(r.cursor)
+	bus.SubInbound(r.inbound)
+	bus.SubKeystroke(r.keystroke)
+	bus.SubOutbound(r.outbound)
+	return r
+}
+
+// 🟦 Public functions
+
+// 👇 the first error writing the trace, if any
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// 🟦 Helpers
+
+func (r *Recorder) cursor(addr uint) {
+	r.record(Event{Addr: &addr, Kind: CURSOR})
+}
+
+func (r *Recorder) inbound(chars []byte, _ core.PubInboundHints) {
+	r.record(Event{Data: Hex(clone(chars)), Kind: INBOUND})
+}
+
+func (r *Recorder) keystroke(key types.Keystroke) {
+	r.record(Event{Key: &key, Kind: KEYSTROKE})
+}
+
+func (r *Recorder) outbound(chars []byte) {
+	r.record(Event{Data: Hex(clone(chars)), Kind: OUTBOUND})
+}
+
+func (r *Recorder) record(evt Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		evt.At = time.Since(r.start)
+		r.err = r.enc.Encode(evt)
+	}
+}
+
+// 🔥 the bus shares buffers that may later be reused
+func clone(chars []byte) []byte {
+	return append([]byte(nil), chars...)
+}
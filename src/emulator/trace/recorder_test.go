@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"bytes"
+	"emulator/conv"
+	"emulator/core"
+	"emulator/tn3270"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 record a screen, some typing and ENTER, then a Read Buffer
+func mockTrace(t *testing.T) *bytes.Buffer {
+	var b bytes.Buffer
+	bus := core.NewBus()
+	r := NewRecorder(bus, &b, 24, 80)
+	p, _ := types.ProfileOf("3279")
+	cfg := &types.Config{Cols: 80, FontHeight: 16, FontWidth: 9, PaddedHeight: 1, PaddedWidth: 1, Rows: 24, SuppressLogs: true}
+	cfg.ApplyProfile(p)
+	core.NewEmulator(bus, cfg).Initialize()
+	bus.PubOutbound(tn3270.MockScreen(types.EW, ""))
+	for _, key := range []string{"h", "i", "Enter"} {
+		bus.PubKeystroke(types.Keystroke{Code: key, Key: key})
+	}
+	bus.PubOutbound([]byte{byte(types.RB)})
+	assert.NoError(t, r.Err())
+	return &b
+}
+
+func TestRecorder(t *testing.T) {
+	tr, err := Read(mockTrace(t))
+	assert.NoError(t, err)
+	assert.Equal(t, SOURCE_RECORDER, tr.Header.Source)
+	assert.Equal(t, uint(24), tr.Header.Rows)
+	kinds := make([]Kind, 0)
+	for _, evt := range tr.Events {
+		kinds = append(kinds, evt.Kind)
+	}
+	// 👇 each inbound record follows what provoked it
+	assert.Equal(t, []Kind{OUTBOUND, KEYSTROKE, KEYSTROKE, KEYSTROKE, INBOUND, OUTBOUND, INBOUND}, kinds)
+	assert.Equal(t, byte(types.ENTER), tr.Events[4].Data[0])
+	assert.Contains(t, conv.E2As(string(tr.Events[4].Data)), "hi")
+	for ix := 1; ix < len(tr.Events); ix++ {
+		assert.GreaterOrEqual(t, tr.Events[ix].At, tr.Events[ix-1].At)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, assert.AnError
+}
+
+func TestRecorderErr(t *testing.T) {
+	r := NewRecorder(core.NewBus(), failingWriter{}, 24, 80)
+	assert.ErrorIs(t, r.Err(), assert.AnError)
+}
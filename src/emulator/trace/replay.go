@@ -0,0 +1,102 @@
+package trace
+
+import (
+	"bytes"
+	"emulator/core"
+	"emulator/types"
+	"fmt"
+)
+
+// 🟧 Replay a trace into a fresh emulator, checking that it answers
+//    the host exactly as it did when the trace was made
+
+// 👇 a difference between what was traced and what was replayed,
+//    where either side may be missing
+
+type Mismatch struct {
+	// 👇 index into the trace's events
+	At   int
+	Got  []byte
+	Want []byte
+}
+
+// 🟦 Public functions
+
+// 👇 the emulator, left as the trace left it, and every mismatch
+func Replay(t *Trace) (emu *core.Emulator, mismatches []Mismatch, err error) {
+	// 👇 only rendering can fail
+	cfg, _ := core.HeadlessConfig(t.Header.Rows, t.Header.Cols, false)
+	// 🔥 must subscribe BEFORE we create the emulator
+	bus := core.NewBus()
+	got := make([][]byte, 0)
+	bus.SubInbound(func(chars []byte, _ core.PubInboundHints) {
+		got = append(got, append([]byte(nil), chars...))
+	})
+	var panicked error
+	bus.SubPanic(func(msg string) {
+		panicked = fmt.Errorf("trace: %s", msg)
+	})
+	emu = core.NewEmulator(bus, cfg).Initialize()
+	mismatches = make([]Mismatch, 0)
+	// 🔥 a customer's trace may well crash the emulator, which is
+	//    just what we want to know
+	var ix int
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("trace: emulator panicked at event %d: %v", ix, r)
+		}
+	}()
+	var evt Event
+	for ix, evt = range t.Events {
+		switch evt.Kind {
+
+		case INBOUND:
+			// 👇 only our own traces have keystrokes, so otherwise
+			//    inbound the user provoked can't be reproduced
+			if len(got) == 0 && t.Header.Source != SOURCE_RECORDER {
+				continue
+			}
+			var next []byte
+			if len(got) > 0 {
+				next, got = got[0], got[1:]
+			}
+			if !sameRecord(next, evt.Data) {
+				mismatches = append(mismatches, Mismatch{At: ix, Got: next, Want: evt.Data})
+			}
+
+		case CURSOR:
+			if evt.Addr != nil {
+				bus.PubCursor(*evt.Addr)
+			}
+
+		case KEYSTROKE:
+			if evt.Key != nil {
+				bus.PubKeystroke(*evt.Key)
+			}
+
+		case OUTBOUND:
+			bus.PubOutbound(evt.Data)
+			// 👇 the emulator survives a program check, but the
+			//    rest of the trace can't be expected to match
+			if err := emu.Out.Err(); err != nil {
+				return emu, mismatches, fmt.Errorf("trace: %w at event %d", err, ix)
+			}
+
+		}
+		if panicked != nil {
+			return emu, mismatches, fmt.Errorf("%w at event %d", panicked, ix)
+		}
+	}
+	// 👇 anything left over was never traced
+	for _, chars := range got {
+		mismatches = append(mismatches, Mismatch{At: len(t.Events), Got: chars})
+	}
+	return emu, mismatches, nil
+}
+
+// 🟦 Helpers
+
+// 👇 compare records without their frame boundary, kept or not
+func sameRecord(a, b []byte) bool {
+	return bytes.Equal(bytes.TrimSuffix(a, types.LT), bytes.TrimSuffix(b, types.LT))
+}
@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"emulator/core"
+	"emulator/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("a faithful replay matches", func(t *testing.T) {
+		tr, _ := Read(mockTrace(t))
+		emu, mismatches, err := Replay(tr)
+		assert.NoError(t, err)
+		assert.Empty(t, mismatches)
+		assert.Equal(t, "hi", emu.Cells.Copy(emu.Cfg.RC2Addr(1, 8), emu.Cfg.RC2Addr(1, 9), false))
+	})
+
+	t.Run("a different answer is a mismatch", func(t *testing.T) {
+		tr, _ := Read(mockTrace(t))
+		tr.Events[4].Data[len(tr.Events[4].Data)-3] ^= 0xff
+		_, mismatches, err := Replay(tr)
+		assert.NoError(t, err)
+		assert.Len(t, mismatches, 1)
+		assert.Equal(t, 4, mismatches[0].At)
+	})
+
+	t.Run("a missing answer is a mismatch", func(t *testing.T) {
+		tr, _ := Read(mockTrace(t))
+		tr.Events = tr.Events[:len(tr.Events)-1]
+		_, mismatches, _ := Replay(tr)
+		assert.Len(t, mismatches, 1)
+		assert.Nil(t, mismatches[0].Want)
+	})
+
+	t.Run("a program check is an error", func(t *testing.T) {
+		tr := &Trace{Header: Header{Cols: 80, Rows: 24}, Events: []Event{{Data: Hex{byte(types.W), 0x00, byte(types.PT)}, Kind: OUTBOUND}}}
+		emu, _, err := Replay(tr)
+		var check *core.ProgCheck
+		assert.ErrorAs(t, err, &check)
+		assert.ErrorContains(t, err, "PROG 753 in W: PT not supported at offset 2 at event 0")
+		assert.Equal(t, "PROG 753", emu.State.Status.Message)
+	})
+
+	t.Run("so is a corrupt stream", func(t *testing.T) {
+		tr := &Trace{Header: Header{Cols: 80, Rows: 24}, Events: []Event{{Data: Hex{byte(types.W), 0x00, byte(types.SBA)}, Kind: OUTBOUND}}}
+		_, _, err := Replay(tr)
+		assert.ErrorContains(t, err, "PROG 754 in W: SBA address truncated at offset 3 at event 0")
+	})
+}
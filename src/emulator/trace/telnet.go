@@ -0,0 +1,160 @@
+package trace
+
+import (
+	"emulator/tn3270"
+	"emulator/utils"
+	"time"
+)
+
+// 🟧 Build a trace from both sides of a Telnet conversation, however
+//    it was captured, by stripping out everything that isn't 3270
+
+type Telnet struct {
+	in  *deframer
+	out *deframer
+	t   *Trace
+}
+
+// 👇 Telnet TN3270E option, which prefixes each record with a header
+const TN3270E byte = 40
+
+// 🟦 Constructor
+
+// 👇 24x80 until the terminal type says otherwise
+func NewTelnet(source string) *Telnet {
+	tn := new(Telnet)
+	tn.t = &Trace{Header: Header{Cols: 80, Rows: 24, Source: source, Version: Version}}
+	tn.in = newDeframer(INBOUND, tn.t)
+	tn.out = newDeframer(OUTBOUND, tn.t)
+	return tn
+}
+
+// 🟦 Public functions
+
+// 👇 OUTBOUND is from the host, INBOUND is to it
+func (tn *Telnet) Feed(kind Kind, at time.Duration, chars []byte) {
+	d := utils.Ternary(kind == OUTBOUND, tn.out, tn.in)
+	d.at = at
+	d.feed(chars)
+	// 👇 the host only switches to TN3270E if both sides agree
+	tn.in.tn3270e = tn.in.asked && tn.out.asked
+	tn.out.tn3270e = tn.in.tn3270e
+}
+
+func (tn *Telnet) Trace() *Trace {
+	return tn.t
+}
+
+// 🟧 Split one direction of a Telnet byte stream into 3270 records
+
+type deframer struct {
+	// 👇 this side has offered or agreed to TN3270E
+	asked   bool
+	at      time.Duration
+	kind    Kind
+	record  []byte
+	state   int
+	sub     []byte
+	t       *Trace
+	tn3270e bool
+	verb    byte
+}
+
+// 🟦 Lookup tables
+
+const (
+	tnData = iota
+	tnCommand
+	tnOption
+	tnSubneg
+	tnSubnegIAC
+)
+
+// 🟦 Constructor
+
+func newDeframer(kind Kind, t *Trace) *deframer {
+	d := new(deframer)
+	d.kind = kind
+	d.t = t
+	return d
+}
+
+// 🟦 Helpers
+
+func (d *deframer) feed(chars []byte) {
+	for _, char := range chars {
+		switch d.state {
+
+		case tnData:
+			if char == tn3270.IAC {
+				d.state = tnCommand
+			} else {
+				d.record = append(d.record, char)
+			}
+
+		case tnCommand:
+			d.state = tnData
+			switch char {
+
+			case tn3270.IAC:
+				d.record = append(d.record, char)
+
+			case tn3270.EOR:
+				d.emit()
+
+			case tn3270.DO, tn3270.DONT, tn3270.WILL, tn3270.WONT:
+				d.state = tnOption
+				d.verb = char
+
+			case tn3270.SB:
+				d.state = tnSubneg
+				d.sub = d.sub[:0]
+
+			}
+
+		case tnOption:
+			if char == TN3270E {
+				d.asked = d.verb == tn3270.DO || d.verb == tn3270.WILL
+			}
+			d.state = tnData
+
+		case tnSubneg:
+			if char == tn3270.IAC {
+				d.state = tnSubnegIAC
+			} else {
+				d.sub = append(d.sub, char)
+			}
+
+		case tnSubnegIAC:
+			d.state = utils.Ternary(char == tn3270.SE, tnData, tnSubneg)
+			if char == tn3270.SE {
+				d.subnegotiated()
+			}
+
+		}
+	}
+}
+
+func (d *deframer) emit() {
+	record := d.record
+	d.record = nil
+	// 👇 TN3270E headers are 5 bytes, and only type 0 is 3270 data
+	if d.tn3270e {
+		if len(record) < 5 || record[0] != 0 {
+			return
+		}
+		record = record[5:]
+	}
+	if len(record) > 0 {
+		d.t.Events = append(d.t.Events, Event{At: d.at, Data: Hex(record), Kind: d.kind})
+	}
+}
+
+// 👇 the terminal type tells us the screen size
+func (d *deframer) subnegotiated() {
+	if len(d.sub) > 2 && d.sub[0] == tn3270.TERMINAL_TYPE && d.sub[1] == tn3270.IS {
+		if rows, cols, ok := tn3270.SizeOf(string(d.sub[2:])); ok {
+			d.t.Header.Rows, d.t.Header.Cols = rows, cols
+		}
+	}
+}
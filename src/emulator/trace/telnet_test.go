@@ -0,0 +1,43 @@
+package trace
+
+import (
+	"emulator/tn3270"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelnet(t *testing.T) {
+	t.Run("records split on EOR, negotiation stripped", func(t *testing.T) {
+		tn := NewTelnet(SOURCE_RECORDER)
+		tn.Feed(OUTBOUND, 0, []byte{0xff, 0xfd, 0x19, 0xf5, 0xc2})
+		tn.Feed(OUTBOUND, time.Second, []byte{0xff, 0xff, 0xff, 0xef})
+		tn.Feed(INBOUND, 2*time.Second, []byte{0xff, 0xfb, 0x19, 0x7d, 0xff, 0xef})
+		assert.Equal(t, []Event{
+			{At: time.Second, Data: Hex{0xf5, 0xc2, 0xff}, Kind: OUTBOUND},
+			{At: 2 * time.Second, Data: Hex{0x7d}, Kind: INBOUND},
+		}, tn.Trace().Events)
+	})
+
+	t.Run("terminal type sets the screen size", func(t *testing.T) {
+		tn := NewTelnet(SOURCE_RECORDER)
+		sb := []byte{tn3270.IAC, tn3270.SB, tn3270.TERMINAL_TYPE, tn3270.IS}
+		sb = append(sb, []byte("IBM-3278-5-E")...)
+		tn.Feed(INBOUND, 0, append(sb, tn3270.IAC, tn3270.SE))
+		assert.Equal(t, uint(27), tn.Trace().Header.Rows)
+		assert.Equal(t, uint(132), tn.Trace().Header.Cols)
+		assert.Empty(t, tn.Trace().Events)
+	})
+
+	t.Run("TN3270E headers are stripped", func(t *testing.T) {
+		tn := NewTelnet(SOURCE_RECORDER)
+		tn.Feed(OUTBOUND, 0, []byte{0xff, 0xfd, TN3270E})
+		tn.Feed(INBOUND, 0, []byte{0xff, 0xfb, TN3270E})
+		// 👇 a BIND image, then 3270 data
+		tn.Feed(OUTBOUND, 0, []byte{0x03, 0, 0, 0, 0, 0xff, 0xef})
+		tn.Feed(OUTBOUND, 0, []byte{0, 0, 0, 0, 1, 0xf5, 0xc2, 0xff, 0xef})
+		assert.Len(t, tn.Trace().Events, 1)
+		assert.Equal(t, Hex{0xf5, 0xc2}, tn.Trace().Events[0].Data)
+	})
+}
@@ -0,0 +1,115 @@
+package trace
+
+import (
+	"bufio"
+	"emulator/types"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 🟧 A trace of one session: what the host sent, what the emulator
+//    answered and which keys the user pressed, in order
+
+// 👇 the file is JSON lines: a header, then one line per event,
+//    with data in hex so that a trace can be read by eye
+
+type Trace struct {
+	Events []Event
+	Header Header
+}
+
+type Header struct {
+	Cols    uint      `json:"cols"`
+	Rows    uint      `json:"rows"`
+	Source  string    `json:"source"`
+	Started time.Time `json:"started"`
+	Version int       `json:"version"`
+}
+
+type Event struct {
+	// 👇 where the cursor was moved, as a pointer because 0 is valid
+	Addr *uint `json:"addr,omitempty"`
+	// 👇 since the trace started
+	At   time.Duration    `json:"at"`
+	Data Hex              `json:"data,omitempty"`
+	Key  *types.Keystroke `json:"key,omitempty"`
+	Kind Kind             `json:"kind"`
+}
+
+type Hex []byte
+
+type Kind string
+
+// 🟦 Lookup tables
+
+const (
+	CURSOR    Kind = "cursor"
+	INBOUND   Kind = "in"
+	KEYSTROKE Kind = "key"
+	OUTBOUND  Kind = "out"
+)
+
+const (
+	SOURCE_PCAP     = "pcap"
+	SOURCE_RECORDER = "recorder"
+	SOURCE_X3270    = "x3270"
+)
+
+// 👇 bump when the format changes incompatibly
+const Version = 1
+
+// 🟦 Constructor
+
+func Read(r io.Reader) (*Trace, error) {
+	t := new(Trace)
+	scanner := bufio.NewScanner(r)
+	// 🔥 a single record may be much bigger than a line of text
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("trace: no header")
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &t.Header); err != nil {
+		return nil, fmt.Errorf("trace: bad header: %w", err)
+	}
+	if t.Header.Version != Version {
+		return nil, fmt.Errorf("trace: version %d, expected %d", t.Header.Version, Version)
+	}
+	for line := 2; scanner.Scan(); line++ {
+		var evt Event
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			return nil, fmt.Errorf("trace: line %d: %w", line, err)
+		}
+		t.Events = append(t.Events, evt)
+	}
+	return t, scanner.Err()
+}
+
+// 🟦 Public functions
+
+func (t *Trace) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(t.Header); err != nil {
+		return err
+	}
+	for _, evt := range t.Events {
+		if err := enc.Encode(evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 🟦 encoding.TextMarshaler implementation
+
+func (h Hex) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(h)), nil
+}
+
+func (h *Hex) UnmarshalText(text []byte) error {
+	chars, err := hex.DecodeString(string(text))
+	*h = chars
+	return err
+}
@@ -0,0 +1,38 @@
+package trace
+
+import (
+	"bytes"
+	"emulator/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceRoundTrip(t *testing.T) {
+	before := &Trace{
+		Header: Header{Cols: 80, Rows: 24, Source: SOURCE_RECORDER, Started: time.Unix(0, 0).UTC(), Version: Version},
+		Events: []Event{
+			{At: time.Millisecond, Data: Hex{0xf5, 0xc3}, Kind: OUTBOUND},
+			{At: 2 * time.Millisecond, Key: &types.Keystroke{Code: "Enter", Key: "Enter"}, Kind: KEYSTROKE},
+			{At: 3 * time.Millisecond, Data: Hex{0x7d, 0x40, 0x40}, Kind: INBOUND},
+		},
+	}
+	var b bytes.Buffer
+	assert.NoError(t, before.Write(&b))
+	assert.Contains(t, b.String(), `"data":"f5c3"`, "data is hex")
+	assert.Equal(t, 4, strings.Count(b.String(), "\n"), "JSON lines")
+	after, err := Read(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, before, after)
+}
+
+func TestTraceReadErrors(t *testing.T) {
+	_, err := Read(strings.NewReader(""))
+	assert.ErrorContains(t, err, "no header")
+	_, err = Read(strings.NewReader(`{"version":99}`))
+	assert.ErrorContains(t, err, "version 99")
+	_, err = Read(strings.NewReader("{\"version\":1}\n{\"data\":\"zz\"}"))
+	assert.ErrorContains(t, err, "line 2")
+}
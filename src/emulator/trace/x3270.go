@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"bufio"
+	"emulator/utils"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// 🟧 Import an x3270 -trace file, so that a customer's session can
+//    be replayed here
+
+// 👇 the lines we need look like these, and we ignore the rest:
+//    Model 3279-4-E, 43 rows x 80 cols, ...
+//    < 0x0   f5c2114040...
+//    > 0x0   7d4ec7...
+//    where < is from the host and > is to it
+
+var (
+	dataLine  = regexp.MustCompile(`^([<>]) 0x([0-9a-fA-F]+)\s+([0-9a-fA-F]+)\s*$`)
+	modelLine = regexp.MustCompile(`(\d+) rows x (\d+) cols`)
+)
+
+// 🟦 Constructor
+
+func ImportX3270(r io.Reader) (*Trace, error) {
+	tn := NewTelnet(SOURCE_X3270)
+	t := tn.Trace()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if m := modelLine.FindStringSubmatch(text); m != nil {
+			rows, _ := strconv.Atoi(m[1])
+			cols, _ := strconv.Atoi(m[2])
+			t.Header.Rows, t.Header.Cols = uint(rows), uint(cols)
+			continue
+		}
+		m := dataLine.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		chars, err := hex.DecodeString(m[3])
+		if err != nil {
+			return nil, fmt.Errorf("trace: x3270 line %d: %w", line, err)
+		}
+		tn.Feed(utils.Ternary(m[1] == "<", OUTBOUND, INBOUND), 0, chars)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.Events) == 0 {
+		return nil, fmt.Errorf("trace: no 3270 data in x3270 trace")
+	}
+	return t, nil
+}
@@ -0,0 +1,54 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 👇 abridged from a real x3270 -trace, with a query and its reply
+var x3270Trace = `Trace started Thu Oct 19 10:00:00 2026
+ Version: x3270 v4.3ga8
+ Model 3279-4-E, 43 rows x 80 cols, color display, extended data stream
+Connected to mainframe, port 23
+< 0x0   fffd18
+RCVD DO TERMINAL-TYPE
+> 0x0   fffb18
+SENT WILL TERMINAL-TYPE
+< 0x0   fffa1801fff0
+> 0x0   fffa180049424d2d333237382d342d45fff0
+< 0x0   fffb19fffd19fffb00fffd00
+> 0x0   fffd19fffb19fffd00fffb00
+< 0x0   f5c21140401d
+< 0x6   60c8c5ffff
+< 0xb   d3d3d6ffef
+< EraseWrite(restore,reset)
+> 0x0   7d4040114040ffef
+`
+
+func TestImportX3270(t *testing.T) {
+	tr, err := ImportX3270(strings.NewReader(x3270Trace))
+	assert.NoError(t, err)
+	assert.Equal(t, Header{Cols: 80, Rows: 43, Source: SOURCE_X3270, Version: Version}, tr.Header)
+	assert.Len(t, tr.Events, 2)
+	// 👇 Telnet is gone, chunks are joined and IAC IAC is unescaped
+	assert.Equal(t, OUTBOUND, tr.Events[0].Kind)
+	assert.Equal(t, Hex{0xf5, 0xc2, 0x11, 0x40, 0x40, 0x1d, 0x60, 0xc8, 0xc5, 0xff, 0xd3, 0xd3, 0xd6}, tr.Events[0].Data)
+	assert.Equal(t, INBOUND, tr.Events[1].Kind)
+	assert.Equal(t, Hex{0x7d, 0x40, 0x40, 0x11, 0x40, 0x40}, tr.Events[1].Data)
+}
+
+func TestImportX3270Errors(t *testing.T) {
+	_, err := ImportX3270(strings.NewReader("nothing to see here\n"))
+	assert.ErrorContains(t, err, "no 3270 data")
+}
+
+func TestReplayX3270(t *testing.T) {
+	tr, _ := ImportX3270(strings.NewReader(x3270Trace))
+	emu, mismatches, err := Replay(tr)
+	assert.NoError(t, err)
+	// 👇 the user's ENTER isn't in the trace, so can't be checked
+	assert.Empty(t, mismatches)
+	assert.Equal(t, uint(43), emu.Cfg.Rows)
+}
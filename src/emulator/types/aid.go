@@ -44,6 +44,7 @@ const (
 	PF22    AID = 0x4a
 	PF23    AID = 0x4b
 	PF24    AID = 0x4c
+	SELPEN  AID = 0x7e
 )
 
 var aids = map[AID]string{
@@ -77,6 +78,7 @@ var aids = map[AID]string{
 	0x4a: "PF22",
 	0x4b: "PF23",
 	0x4c: "PF24",
+	0x7e: "SELPEN",
 }
 
 var aidsLookup = make(map[string]AID)
@@ -130,6 +132,12 @@ func AIDFor(a AID) string {
 	return aids[a]
 }
 
+// 👇 the AID by name, like "ENTER" or "pf3"
+func AIDNamed(name string) (AID, bool) {
+	aid, ok := aidsLookup[strings.ToUpper(name)]
+	return aid, ok
+}
+
 func (a AID) String() string {
 	return AIDFor(a)
 }
@@ -40,3 +40,16 @@ func TestAIDStringer(t *testing.T) {
 	assert.Equal(t, "ENTER", ENTER.String(), "ENTER stringified")
 	assert.Equal(t, "ENTER", AIDFor(ENTER), "ENTER stringified")
 }
+
+func TestAIDNamed(t *testing.T) {
+	aid, ok := AIDNamed("pf3")
+	assert.True(t, ok)
+	assert.Equal(t, PF3, aid)
+	_, ok = AIDNamed("PF99")
+	assert.False(t, ok)
+}
+
+func TestSELPEN(t *testing.T) {
+	assert.Equal(t, "SELPEN", SELPEN.String(), "SELPEN stringified")
+	assert.False(t, SELPEN.ShortRead(), "SELPEN does not trigger a short read")
+}
@@ -10,6 +10,7 @@ type Attrs struct {
 	Autoskip   bool
 	Blink      bool
 	Color      Color
+	Detectable bool
 	Hidden     bool
 	Highlight  bool
 	Intensify  bool
@@ -76,7 +77,11 @@ func NewModifiedAttrs(attrs *Attrs, chars []byte) *Attrs {
 	return &a
 }
 
+// 👇 the display bits: 00 normal, 01 normal and selector-pen detectable,
+//    10 intensified (Highlight) and also detectable, 11 nondisplay
+
 func (a *Attrs) fromBits(char byte) {
+	a.Detectable = ((char & 0b00001000) == 0) && ((char & 0b00000100) != 0)
 	a.Hidden = ((char & 0b00001000) != 0) && ((char & 0b00000100) != 0)
 	a.Highlight = ((char & 0b00001000) != 0) && ((char & 0b00000100) == 0)
 	a.MDT = (char & 0b00000001) != 0
@@ -131,6 +136,9 @@ func (a *Attrs) fromBytes(chars []byte) {
 
 func (a *Attrs) Bits() byte {
 	var char byte = 0b00000000
+	if a.Detectable && !a.Highlight {
+		char |= 0b00000100
+	}
 	if a.Hidden {
 		char |= 0b00001100
 	}
@@ -213,6 +221,9 @@ func AttrsFor(a *Attrs) string {
 		b.WriteString(ColorFor(a.Color))
 		b.WriteString(" ")
 	}
+	if a.Detectable {
+		b.WriteString("DETECT ")
+	}
 	if a.Hidden {
 		b.WriteString("HIDDEN ")
 	}
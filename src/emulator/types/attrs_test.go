@@ -58,6 +58,14 @@ func TestAttrsBits(t *testing.T) {
 	assert.Equal(t, a.Bits(), byte(0b00111101), "decode attrs to bit settings")
 }
 
+func TestAttrsDetectable(t *testing.T) {
+	for bits, detectable := range map[byte]bool{0b00: false, 0b01: true, 0b10: false, 0b11: false} {
+		a := NewBasicAttrs(bits << 2)
+		assert.Equal(t, detectable, a.Detectable, "display bits %02b", bits)
+		assert.Equal(t, bits<<2, a.Bits(), "display bits %02b survive", bits)
+	}
+}
+
 func TestAttrsBytes(t *testing.T) {
 	a := &Attrs{
 		Autoskip:   true,
@@ -126,3 +134,14 @@ func TestAttrsStringer(t *testing.T) {
 	assert.Equal(t, str, a.String(), "attrs stringified")
 	assert.Equal(t, str, AttrsFor(a), "attrs stringified")
 }
+
+// 👇 fromBytes underlies both SFE and SA/MF, so any pairs must be safe
+func FuzzNewExtendedAttrs(f *testing.F) {
+	f.Add([]byte{byte(BASIC), 0b00111101, byte(HIGHLIGHT), byte(UNDERSCORE), byte(COLOR), 0xf4})
+	f.Add([]byte{byte(CHARSET)})
+	f.Fuzz(func(t *testing.T, chars []byte) {
+		a := NewExtendedAttrs(chars)
+		assert.True(t, NewModifiedAttrs(a, chars).CharAttr)
+		assert.NotNil(t, a.Bytes())
+	})
+}
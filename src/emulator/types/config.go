@@ -1,6 +1,7 @@
 package types
 
 import (
+	"emulator/fonts"
 	"image"
 
 	"golang.org/x/image/font"
@@ -9,21 +10,36 @@ import (
 // 🟧 Go3270 configuration parameters
 
 type Config struct {
+	BaseColors   BaseColors
 	BgColor      string
 	BoldFace     *font.Face
 	CLUT         map[Color]string
+	CodePage     string
 	Cols         uint
+	CursorColor  string
+	CursorStyle  CursorStyle
+	DPI          float64
+	Family       fonts.Family
 	FontHeight   float64
 	FontSize     float64
 	FontWidth    float64
 	Monochrome   bool
 	NormalFace   *font.Face
+	OIA          bool
+	OIAColor     string
+	Overlay      bool
 	PaddedHeight float64
 	PaddedWidth  float64
 	RGBA         *image.RGBA
+	RevealHidden bool
 	Rows         uint
+	Ruler        bool
+	RulerColor   string
+	SteadyCursor bool
 	SuppressLogs bool
 	Testpage     string
+	WheelDown    AID
+	WheelUp      AID
 }
 
 // 🟦 Public functions
@@ -38,23 +54,30 @@ func (c *Config) RC2Addr(row, col uint) uint {
 	return (row-1)*c.Cols + col - 1
 }
 
+func (c *Config) ApplyProfile(p Profile) {
+	c.BaseColors = p.BaseColors
+	c.BgColor = p.BgColor
+	// 🔥 copy the CLUT so the profile itself is never changed
+	c.CLUT = make(map[Color]string, len(p.CLUT))
+	for k, v := range p.CLUT {
+		c.CLUT[k] = v
+	}
+	c.CursorColor = p.CursorColor
+	c.Monochrome = p.Monochrome
+	c.OIAColor = p.OIAColor
+	c.RulerColor = p.RulerColor
+}
+
 func (c *Config) ColorOf(a *Attrs) string {
 	var ix Color
 	if c.Monochrome {
+		// 👇 intensified is brighter, if the CLUT distinguishes it
 		ix = GREEN
-	} else if a.Color == 0x00 {
-		switch {
-		case !a.Protected && (a.Highlight || a.Hidden):
-			ix = RED
-		case !a.Protected && !a.Highlight:
-			ix = GREEN
-		case a.Protected && (a.Highlight || a.Hidden):
-			ix = FOREGROUND
-		case a.Protected && !a.Highlight:
-			ix = BLUE
-		default:
-			ix = 0xf4
+		if _, ok := c.CLUT[PALE_GREEN]; ok && (a.Highlight || a.Intensify) {
+			ix = PALE_GREEN
 		}
+	} else if a.Color == 0x00 {
+		ix = c.BaseColors.Of(a.Protected, a.Highlight || a.Hidden)
 	} else {
 		ix = a.Color
 	}
@@ -46,3 +46,18 @@ func TestConfigColorOfMono(t *testing.T) {
 	a := &Attrs{}
 	assert.Equal(t, "GREEN", c.ColorOf(a), "monochrome display is 'green'")
 }
+
+func TestConfigColorOfMonoIntensified(t *testing.T) {
+	c := &Config{Monochrome: true, CLUT: map[Color]string{0xf4: "GREEN", 0xfc: "BRIGHT"}}
+	a := &Attrs{Highlight: true}
+	assert.Equal(t, "BRIGHT", c.ColorOf(a), "intensified monochrome is brighter")
+}
+
+func TestConfigColorOfRemapped(t *testing.T) {
+	c := &Config{
+		BaseColors: BaseColors{ProtectedNormal: TURQUOISE},
+		CLUT:       map[Color]string{0xf1: "BLUE", 0xf5: "TURQUOISE"},
+	}
+	a := &Attrs{Protected: true}
+	assert.Equal(t, "TURQUOISE", c.ColorOf(a), "base color remapped")
+}
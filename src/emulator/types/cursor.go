@@ -0,0 +1,41 @@
+package types
+
+// 🟧 Cursor style, as drawn on the screen
+
+type CursorStyle byte
+
+// 🟦 Lookup tables
+
+const (
+	CURSOR_BLOCK     CursorStyle = 0x00
+	CURSOR_UNDERLINE CursorStyle = 0x01
+	CURSOR_BAR       CursorStyle = 0x02
+)
+
+var cursorStyles = map[CursorStyle]string{
+	0x00: "BLOCK",
+	0x01: "UNDERLINE",
+	0x02: "BAR",
+}
+
+// 🟦 Public functions
+
+// 👇 unknown styles fall back to the traditional block
+func CursorStyleOf(str string) CursorStyle {
+	for k, v := range cursorStyles {
+		if v == str {
+			return k
+		}
+	}
+	return CURSOR_BLOCK
+}
+
+// 🟦 Stringer implementation
+
+func CursorStyleFor(c CursorStyle) string {
+	return cursorStyles[c]
+}
+
+func (c CursorStyle) String() string {
+	return CursorStyleFor(c)
+}
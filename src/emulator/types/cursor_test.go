@@ -0,0 +1,17 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorStyleOf(t *testing.T) {
+	assert.Equal(t, CURSOR_BAR, CursorStyleOf("BAR"), "BAR found")
+	assert.Equal(t, CURSOR_BLOCK, CursorStyleOf("xxx"), "default to BLOCK")
+}
+
+func TestCursorStyleStringer(t *testing.T) {
+	assert.Equal(t, "UNDERLINE", CURSOR_UNDERLINE.String(), "UNDERLINE stringified")
+	assert.Equal(t, "UNDERLINE", CursorStyleFor(CURSOR_UNDERLINE), "UNDERLINE stringified")
+}
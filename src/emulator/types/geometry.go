@@ -0,0 +1,15 @@
+package types
+
+import "emulator/fonts"
+
+// 🟧 Geometry of the screen, as changed at runtime by zoom or resize
+
+// 👇 zero values leave the current setting unchanged
+
+type Geometry struct {
+	DPI          float64
+	Family       fonts.Family
+	FontSize     float64
+	PaddedHeight float64
+	PaddedWidth  float64
+}
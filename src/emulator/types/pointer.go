@@ -0,0 +1,14 @@
+package types
+
+// 🟧 Pointer (mouse) event, as submitted by Typescript UI
+
+// 👇 Type is the DOM event type: "click", "dblclick" or "wheel"
+//    X and Y are offsets in pixels into the canvas
+//    DeltaY is only meaningful for "wheel"
+
+type Pointer struct {
+	DeltaY float64
+	Type   string
+	X      float64
+	Y      float64
+}
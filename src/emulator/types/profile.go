@@ -0,0 +1,160 @@
+package types
+
+// 🟧 Display profiles: named color themes for the screen
+
+// 🔥 a monochrome CLUT carries normal phosphor in GREEN and
+//    bright (intensified) phosphor in PALE_GREEN
+
+type Profile struct {
+	BaseColors  BaseColors
+	BgColor     string
+	CLUT        map[Color]string
+	CursorColor string
+	Monochrome  bool
+	Name        string
+	OIAColor    string
+	RulerColor  string
+}
+
+// 👇 the colors of the four 3279 base color combinations,
+//    used when the host doesn't specify an extended color
+
+type BaseColors struct {
+	ProtectedIntensified   Color
+	ProtectedNormal        Color
+	UnprotectedIntensified Color
+	UnprotectedNormal      Color
+}
+
+// 🟦 Lookup tables
+
+var DefaultBaseColors = BaseColors{
+	ProtectedIntensified:   FOREGROUND,
+	ProtectedNormal:        BLUE,
+	UnprotectedIntensified: RED,
+	UnprotectedNormal:      GREEN,
+}
+
+var profiles = map[string]Profile{
+	"3278-amber":    phosphor("3278-amber", "#1a1000", "#c88a00", "#ffb000"),
+	"3278-green":    phosphor("3278-green", "#0a140a", "#2fb84a", "#66ff80"),
+	"3278-white":    phosphor("3278-white", "#141414", "#b4b4b4", "#ffffff"),
+	"3279":          colors3279("3279", "#202020", "#ffffff"),
+	"high-contrast": highContrast(),
+	"light":         light(),
+}
+
+// 🟦 Public functions
+
+func ProfileOf(name string) (Profile, bool) {
+	p, ok := profiles[name]
+	return p, ok
+}
+
+func ProfileNames() []string {
+	return []string{"3278-amber", "3278-green", "3278-white", "3279", "high-contrast", "light"}
+}
+
+// 👇 zero colors fall back to the 3279 defaults
+func (b BaseColors) Of(protected, intensified bool) Color {
+	var c, dflt Color
+	switch {
+
+	case protected && intensified:
+		c, dflt = b.ProtectedIntensified, DefaultBaseColors.ProtectedIntensified
+
+	case protected:
+		c, dflt = b.ProtectedNormal, DefaultBaseColors.ProtectedNormal
+
+	case intensified:
+		c, dflt = b.UnprotectedIntensified, DefaultBaseColors.UnprotectedIntensified
+
+	default:
+		c, dflt = b.UnprotectedNormal, DefaultBaseColors.UnprotectedNormal
+
+	}
+	if c == 0x00 {
+		return dflt
+	}
+	return c
+}
+
+// 🟦 Helpers
+
+// 👇 every color is the same phosphor, at one of two intensities
+func phosphor(name, bg, normal, bright string) Profile {
+	clut := make(map[Color]string)
+	for c := range colors {
+		clut[c] = normal
+	}
+	clut[BACKGROUND] = bg
+	clut[BLACK] = bg
+	clut[PALE_GREEN] = bright
+	return Profile{
+		BgColor:     bg,
+		CLUT:        clut,
+		CursorColor: bright,
+		Monochrome:  true,
+		Name:        name,
+		OIAColor:    normal,
+		RulerColor:  normal,
+	}
+}
+
+func colors3279(name, bg, fg string) Profile {
+	return Profile{
+		BaseColors: DefaultBaseColors,
+		BgColor:    bg,
+		CLUT: map[Color]string{
+			BACKGROUND:     bg,
+			BLUE:           "#4169e1",
+			RED:            "#ff0000",
+			PINK:           "#ee82ee",
+			GREEN:          "#04c304",
+			TURQUOISE:      "#40e0d0",
+			YELLOW:         "#ffff00",
+			FOREGROUND:     fg,
+			BLACK:          bg,
+			DEEP_BLUE:      "#0000cd",
+			ORANGE:         "#ffa500",
+			PURPLE:         "#800080",
+			PALE_GREEN:     "#90ee90",
+			PALE_TURQUOISE: "#afeeee",
+			GREY:           "#c0c0c0",
+			WHITE:          "#e2e2e9",
+		},
+		Name:       name,
+		OIAColor:   fg,
+		RulerColor: "#800080",
+	}
+}
+
+func highContrast() Profile {
+	p := colors3279("high-contrast", "#000000", "#ffffff")
+	p.CLUT[BLUE] = "#00bfff"
+	p.CLUT[GREEN] = "#00ff00"
+	p.CLUT[RED] = "#ff4040"
+	p.CLUT[DEEP_BLUE] = "#4080ff"
+	p.CLUT[PURPLE] = "#ff00ff"
+	p.CLUT[WHITE] = "#ffffff"
+	p.CursorColor = "#ffff00"
+	p.RulerColor = "#ffff00"
+	return p
+}
+
+func light() Profile {
+	p := colors3279("light", "#fafafa", "#000000")
+	p.CLUT[BLUE] = "#1a4fc4"
+	p.CLUT[GREEN] = "#007a00"
+	p.CLUT[RED] = "#c00000"
+	p.CLUT[PINK] = "#b0309e"
+	p.CLUT[TURQUOISE] = "#00838f"
+	p.CLUT[YELLOW] = "#8a6d00"
+	p.CLUT[ORANGE] = "#c25e00"
+	p.CLUT[PALE_GREEN] = "#2e8b57"
+	p.CLUT[PALE_TURQUOISE] = "#3b8686"
+	p.CLUT[GREY] = "#606060"
+	p.CLUT[WHITE] = "#202020"
+	p.RulerColor = "#c0c0ff"
+	return p
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileOf(t *testing.T) {
+	for _, name := range ProfileNames() {
+		p, ok := ProfileOf(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, name, p.Name)
+		assert.Len(t, p.CLUT, 16, name)
+	}
+	_, ok := ProfileOf("xxx")
+	assert.False(t, ok)
+}
+
+func TestBaseColorsOf(t *testing.T) {
+	assert.Equal(t, BLUE, BaseColors{}.Of(true, false), "zero falls back to default")
+	b := BaseColors{UnprotectedNormal: YELLOW}
+	assert.Equal(t, YELLOW, b.Of(false, false), "remapped")
+	assert.Equal(t, RED, b.Of(false, true), "not remapped")
+}
+
+func TestConfigApplyProfile(t *testing.T) {
+	c := &Config{}
+	p, _ := ProfileOf("3278-amber")
+	c.ApplyProfile(p)
+	assert.True(t, c.Monochrome)
+	assert.Equal(t, p.BgColor, c.BgColor)
+	// 👇 the profile's CLUT is copied, not shared
+	c.CLUT[GREEN] = "#000000"
+	assert.NotEqual(t, "#000000", p.CLUT[GREEN])
+}
@@ -4,6 +4,7 @@ package types
 
 type Status struct {
 	Alarm     bool
+	Connected bool
 	CursorAt  uint
 	Error     bool
 	Insert    bool
@@ -16,6 +17,7 @@ type Status struct {
 
 type Patch struct {
 	Alarm     *bool
+	Connected *bool
 	CursorAt  *uint
 	Error     *bool
 	Insert    *bool
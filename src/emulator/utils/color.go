@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// 🟦 Parse a CSS-style hex color (#rgb, #rrggbb or #rrggbbaa)
+
+func HexColor(hex string) color.RGBA {
+	hex = strings.TrimPrefix(hex, "#")
+	// 👇 expand the short form
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	u32, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.RGBA{}
+	}
+	return color.RGBA{R: uint8(u32 >> 24), G: uint8(u32 >> 16), B: uint8(u32 >> 8), A: uint8(u32)}
+}
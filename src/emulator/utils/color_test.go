@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexColor(t *testing.T) {
+	assert.Equal(t, color.RGBA{0x04, 0xc3, 0x04, 0xff}, HexColor("#04c304"), "long form")
+	assert.Equal(t, color.RGBA{0xff, 0x00, 0xff, 0xff}, HexColor("#f0f"), "short form")
+	assert.Equal(t, color.RGBA{0x12, 0x34, 0x56, 0x78}, HexColor("12345678"), "with alpha")
+	assert.Equal(t, color.RGBA{}, HexColor("#zzz"), "garbage")
+}